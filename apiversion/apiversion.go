@@ -0,0 +1,9 @@
+// apiversion/apiversion.go
+package apiversion
+
+// URL prefixes of the API versions. A new version gets its own prefix and
+// route registration (see routes_v1.go); versions share the services layer
+// and differ only in their handlers and request/response shapes.
+const (
+    V1 = "/api/v1"
+)
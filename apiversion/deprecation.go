@@ -0,0 +1,29 @@
+// apiversion/deprecation.go
+package apiversion
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/bhushangupta162/bank_management/metrics"
+)
+
+// Deprecated marks the routes of a group as deprecated. Responses carry a
+// Deprecation header (RFC 9745) with the date the routes were deprecated, a
+// Sunset header (RFC 8594) with the date they may be removed, and a Link to
+// the same path under successorPrefix, e.g. "/api/v1".
+func Deprecated(deprecatedAt, sunset time.Time, successorPrefix string) gin.HandlerFunc {
+    deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+    sunsetDate := sunset.UTC().Format(http.TimeFormat)
+    return func(c *gin.Context) {
+        h := c.Writer.Header()
+        h.Set("Deprecation", deprecation)
+        h.Set("Sunset", sunsetDate)
+        h.Add("Link", "<"+successorPrefix+c.Request.URL.Path+`>; rel="successor-version"`)
+        metrics.DeprecatedRequest(c.Request.Method, c.FullPath())
+        c.Next()
+    }
+}
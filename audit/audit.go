@@ -0,0 +1,189 @@
+// audit/audit.go
+package audit
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "reflect"
+    "strconv"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// GenesisHash is the PrevHash of the first entry in the chain.
+const GenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// chainLockKey is the Postgres advisory lock that serializes appends, so
+// every entry is chained to the one written just before it.
+const chainLockKey = 7_303_001
+
+// ComputeHash returns the SHA-256 over the entry's fields and PrevHash.
+// Fields are length-prefixed so values cannot be shifted between fields.
+func ComputeHash(e *models.AuditEntry) string {
+    actor := ""
+    if e.ActorID != nil {
+        actor = strconv.FormatUint(uint64(*e.ActorID), 10)
+    }
+    fields := []string{
+        e.PrevHash,
+        strconv.FormatUint(uint64(e.ID), 10),
+        e.CreatedAt.UTC().Format(time.RFC3339Nano),
+        actor,
+        e.Action,
+        e.EntityType,
+        e.EntityID,
+        e.Before,
+        e.After,
+        e.Diff,
+        strconv.Itoa(e.StatusCode),
+        e.IP,
+        e.RequestID,
+    }
+
+    h := sha256.New()
+    for _, f := range fields {
+        fmt.Fprintf(h, "%d:%s|", len(f), f)
+    }
+    return hex.EncodeToString(h.Sum(nil))
+}
+
+// Append adds an entry to the end of the chain. The ID is reserved from the
+// table's sequence first so it can be part of the hash.
+func Append(db *gorm.DB, entry *models.AuditEntry) error {
+    return db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", chainLockKey).Error; err != nil {
+            return err
+        }
+
+        var last models.AuditEntry
+        err := tx.Order("id DESC").Limit(1).Find(&last).Error
+        if err != nil {
+            return err
+        }
+        entry.PrevHash = GenesisHash
+        if last.ID != 0 {
+            entry.PrevHash = last.Hash
+        }
+
+        var id uint
+        if err := tx.Raw("SELECT nextval(pg_get_serial_sequence('audit_entries', 'id'))").Scan(&id).Error; err != nil {
+            return err
+        }
+        entry.ID = id
+        // Postgres keeps microseconds; truncate so the stored value hashes the same
+        entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+        entry.Hash = ComputeHash(entry)
+        return tx.Create(entry).Error
+    })
+}
+
+// VerifyResult describes the outcome of a chain check.
+type VerifyResult struct {
+    Checked  int    `json:"checked"`
+    Valid    bool   `json:"valid"`
+    BrokenAt uint   `json:"broken_at,omitempty"` // First entry that does not verify
+    Reason   string `json:"reason,omitempty"`
+}
+
+// Verify walks the whole chain in ID order (FindInBatches orders by primary
+// key) and recomputes every hash.
+func Verify(db *gorm.DB) (VerifyResult, error) {
+    result := VerifyResult{Valid: true}
+    prev := GenesisHash
+
+    var batch []models.AuditEntry
+    err := db.FindInBatches(&batch, 1000, func(tx *gorm.DB, _ int) error {
+        for i := range batch {
+            e := &batch[i]
+            result.Checked++
+            if e.PrevHash != prev {
+                result.Valid, result.BrokenAt, result.Reason = false, e.ID, "prev_hash does not match the previous entry (entry missing or reordered)"
+                return errStop
+            }
+            if ComputeHash(e) != e.Hash {
+                result.Valid, result.BrokenAt, result.Reason = false, e.ID, "hash mismatch (entry modified)"
+                return errStop
+            }
+            prev = e.Hash
+        }
+        return nil
+    }).Error
+    if err != nil && !errors.Is(err, errStop) {
+        return result, err
+    }
+    return result, nil
+}
+
+var errStop = errors.New("stop")
+
+// EnsureImmutable installs a trigger that rejects UPDATE and DELETE on the
+// audit table, so the application cannot change history even by mistake.
+func EnsureImmutable(db *gorm.DB) error {
+    return db.Exec(`
+CREATE OR REPLACE FUNCTION audit_entries_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_entries_no_change ON audit_entries;
+CREATE TRIGGER audit_entries_no_change BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION audit_entries_immutable();
+`).Error
+}
+
+// Snapshot renders a value as JSON for the Before/After fields.
+func Snapshot(v interface{}) string {
+    if v == nil {
+        return ""
+    }
+    b, err := json.Marshal(v)
+    if err != nil {
+        return ""
+    }
+    return string(b)
+}
+
+// Diff compares two JSON objects and returns {"field": {"from": x, "to": y}}
+// for every top-level field that changed. Timestamps that always change on
+// save (updated_at) are ignored.
+func Diff(before, after string) string {
+    var b, a map[string]interface{}
+    if before != "" {
+        if err := json.Unmarshal([]byte(before), &b); err != nil {
+            return ""
+        }
+    }
+    if after != "" {
+        if err := json.Unmarshal([]byte(after), &a); err != nil {
+            return ""
+        }
+    }
+
+    changes := map[string]map[string]interface{}{}
+    for k, av := range a {
+        if k == "updated_at" {
+            continue
+        }
+        if bv, ok := b[k]; !ok || !reflect.DeepEqual(bv, av) {
+            changes[k] = map[string]interface{}{"from": b[k], "to": av}
+        }
+    }
+    for k, bv := range b {
+        if _, ok := a[k]; !ok && !strings.EqualFold(k, "updated_at") {
+            changes[k] = map[string]interface{}{"from": bv, "to": nil}
+        }
+    }
+    if len(changes) == 0 {
+        return ""
+    }
+    out, _ := json.Marshal(changes)
+    return string(out)
+}
@@ -0,0 +1,38 @@
+// cmd/audit-verify/main.go
+//
+// audit-verify recomputes the audit log hash chain and exits with status 1 if
+// any entry was modified, removed or reordered. It uses the same database
+// settings as the server.
+package main
+
+import (
+    "fmt"
+    "log"
+    "os"
+
+    "gorm.io/driver/postgres"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+)
+
+func main() {
+    cfg := config.Load()
+
+    db, err := gorm.Open(postgres.Open(cfg.DatabaseDSN), &gorm.Config{})
+    if err != nil {
+        log.Fatal("Failed to connect to database:", err)
+    }
+
+    result, err := audit.Verify(db)
+    if err != nil {
+        log.Fatal("Failed to read audit log:", err)
+    }
+
+    if !result.Valid {
+        fmt.Printf("audit log BROKEN at entry %d after %d entries: %s\n", result.BrokenAt, result.Checked, result.Reason)
+        os.Exit(1)
+    }
+    fmt.Printf("audit log OK: %d entries verified\n", result.Checked)
+}
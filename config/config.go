@@ -0,0 +1,450 @@
+// config/config.go
+package config
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// Config holds the runtime settings for the application.
+// Every value can be overridden with an environment variable.
+type Config struct {
+    DatabaseDSN         string
+    BootstrapAdminEmail string   // If set, this user is promoted to admin on startup
+    TrustedProxies      []string // IPs or CIDRs allowed to set X-Forwarded-For; none by default
+    MFA                 MFAConfig
+    Mail                MailConfig
+    Email               EmailConfig
+    AccountNumbers      AccountNumberConfig
+    Limits              LimitsConfig
+    Scheduler           SchedulerConfig
+    Webhooks            WebhookConfig
+    Log                 LogConfig
+    Metrics             MetricsConfig
+    Tracing             TracingConfig
+    RateLimit           RateLimitConfig
+    API                 APIConfig
+    GRPC                GRPCConfig
+    Stream              StreamConfig
+    Approval            ApprovalConfig
+    Underwriting        UnderwritingConfig
+    Loan                LoanConfig
+    Overdraft           OverdraftConfig
+    Holds               HoldConfig
+}
+
+// MFAConfig controls TOTP two-factor authentication.
+type MFAConfig struct {
+    Issuer            string        // Shown in authenticator apps next to the account name
+    ChallengeTTL      time.Duration // How long the MFA challenge token from /login stays valid
+    StepUpThreshold   float64       // Transfers above this amount need a TOTP code (0 disables step-up)
+    RecoveryCodeCount int           // Number of recovery codes issued on enrollment
+}
+
+// MailConfig selects and configures the outgoing mail transport.
+type MailConfig struct {
+    Driver       string // "smtp", "file", "memory" or "log"
+    From         string
+    SMTPHost     string
+    SMTPPort     string
+    SMTPUser     string
+    SMTPPassword string
+    Dir          string // Output directory for the "file" driver
+}
+
+// EmailConfig controls email verification and password reset.
+type EmailConfig struct {
+    BaseURL         string        // Used to build the links in emails
+    VerificationTTL time.Duration // Lifetime of an email verification token
+    ResetTTL        time.Duration // Lifetime of a password reset token
+    RequireVerified bool          // Block money movement until the owner's email is verified
+}
+
+// AccountNumberConfig controls generated account numbers and IBANs.
+type AccountNumberConfig struct {
+    Length       int    // Digits in an account number, including the check digit
+    IBANEnabled  bool   // Also assign an IBAN to every account
+    IBANCountry  string // ISO 3166 country code, e.g. "DE"
+    IBANBankCode string // National bank code placed in front of the account number
+}
+
+// LimitsConfig holds the fallback limits for account products that have no
+// limit row in the database. Zero means unlimited.
+type LimitsConfig struct {
+    SingleMax           float64
+    DailyMax            float64
+    MonthlyMax          float64
+    HourlyTransferCount int
+}
+
+// SchedulerConfig controls the scheduled transfer worker.
+type SchedulerConfig struct {
+    Enabled     bool          // Run the worker in this process
+    Interval    time.Duration // How often to look for due transfers
+    BatchSize   int           // Max schedules per run
+    MaxAttempts int           // Attempts per occurrence before it is skipped (or a one-off fails)
+    RetryDelay  time.Duration // Wait between attempts
+}
+
+// WebhookConfig controls outbound webhook delivery.
+type WebhookConfig struct {
+    Enabled     bool          // Run the dispatcher in this process
+    Interval    time.Duration // How often to dispatch the outbox and send due deliveries
+    BatchSize   int
+    Timeout     time.Duration // Per request
+    MaxAttempts int           // After this many failures a delivery goes to the dead-letter list
+    BaseBackoff time.Duration // First retry delay; doubles on every attempt
+    MaxBackoff  time.Duration
+}
+
+// LogConfig controls structured logging.
+type LogConfig struct {
+    Level  string // "debug", "info", "warn" or "error"
+    Format string // "json" or "text"
+}
+
+// MetricsConfig controls the Prometheus endpoint.
+type MetricsConfig struct {
+    Token string // If set, /metrics requires "Authorization: Bearer <token>"
+}
+
+// TracingConfig controls OpenTelemetry tracing.
+type TracingConfig struct {
+    Exporter     string  // "none", "stdout", "file" or "otlp"
+    File         string  // Output file for the "file" exporter (one JSON span per line)
+    OTLPEndpoint string  // e.g. "http://localhost:4318/v1/traces" for the "otlp" exporter
+    ServiceName  string
+    SampleRatio  float64 // Fraction of new traces to record; incoming sampled traces are always kept
+}
+
+// RateLimitConfig controls the per-route-group token bucket limiter.
+type RateLimitConfig struct {
+    Enabled bool
+    Store   string        // "memory" (per process) or "postgres" (shared by all instances)
+    Default RateLimitRule // Every route, keyed by user or IP
+    Auth    RateLimitRule // Signup, login and password reset, keyed by IP
+    Money   RateLimitRule // Deposits, withdrawals and transfers, keyed by user or IP
+}
+
+// APIConfig controls the unversioned legacy aliases of the /api/v1 routes.
+type APIConfig struct {
+    LegacyRoutes bool      // Serve the old unprefixed paths as deprecated aliases
+    DeprecatedAt time.Time // Sent in the Deprecation header of legacy responses
+    Sunset       time.Time // Sent in the Sunset header; the aliases may be removed after this date
+}
+
+// GRPCConfig controls the gRPC server that runs next to the REST API.
+type GRPCConfig struct {
+    Enabled          bool
+    Addr             string        // Listen address, e.g. ":9090"
+    Reflection       bool          // Lets tools like grpcurl discover the services
+    FeedPollInterval time.Duration // How often WatchTransactions checks for new transactions
+}
+
+// StreamConfig controls the real-time account streams (GET /accounts/:id/stream).
+type StreamConfig struct {
+    PollInterval time.Duration // How often the relay reads new transactions from the outbox
+    Heartbeat    time.Duration // Comment line sent on idle streams so proxies keep them open
+    Buffer       int           // Events queued per subscriber before a slow one is disconnected
+}
+
+// ApprovalConfig controls maker-checker approvals (pending actions).
+type ApprovalConfig struct {
+    TTL               time.Duration // Pending actions expire after this long
+    TransferThreshold float64       // Transfers of at least this amount need approval; 0 turns it off
+    ExpiryInterval    time.Duration // How often the expiry job runs
+}
+
+// UnderwritingConfig holds the rules the loan underwriting engine applies to
+// every application.
+type UnderwritingConfig struct {
+    AutoDecide          bool    // Apply approve/decline outcomes at once; otherwise every loan waits for staff
+    LookbackDays        int     // Account history the engine looks at
+    MinAccountAgeDays   int     // Younger customers are referred to staff
+    MaxPrincipal        float64 // Larger applications are declined
+    MaxTermMonths       int     // Longer terms are declined
+    AutoApproveLimit    float64 // Larger applications are referred even when everything else passes
+    MinAverageBalance   float64 // Lower average balances are referred
+    MaxPaymentToInflow  float64 // Largest share of monthly inflows the installment may take
+    MaxExposureToInflow float64 // Largest total loan balance, in months of inflows, before referral
+    BaseRate            float64 // Annual rate in percent for the best score band
+    LongTermPremium     float64 // Added to the rate for terms over 36 months
+    MaxRate             float64 // Priced rates are capped here
+}
+
+// LoanConfig controls the servicing of disbursed loans.
+type LoanConfig struct {
+    DelinquencyInterval time.Duration // How often the delinquency job runs
+    DefaultAfterDays    int           // Delinquent loans this many days past due default
+    RepaymentOrder      []string      // Order repayments pay what is due in: "fees", "interest", "principal"
+    Prepayment          string        // What paying ahead of schedule does by default: "shorten_term" or "reduce_installment"
+    CollectionInterval  time.Duration // How often the direct debit job looks for due installments
+}
+
+// OverdraftConfig controls overdraft facilities on accounts.
+type OverdraftConfig struct {
+    Products         []string      // Account products an overdraft can be granted on
+    MaxLimit         float64       // Largest overdraft staff can grant (0 means no cap)
+    InterestRate     float64       // Default annual rate in percent on a negative balance
+    Fee              float64       // Default fee for going below zero
+    InterestInterval time.Duration // How often the interest job looks for overdrawn accounts
+}
+
+// HoldConfig controls holds (authorizations) on account funds.
+type HoldConfig struct {
+    DefaultTTL     time.Duration // How long a hold lasts when the request sets no expiry
+    MaxTTL         time.Duration // Longest expiry a hold may be given
+    ExpiryInterval time.Duration // How often the expiry job releases holds past their expiry
+}
+
+// RateLimitRule is a token bucket: Requests tokens refill evenly over Period,
+// and at most Burst can be saved up. Written as "requests/period,burst" in the
+// environment, e.g. "10/1m,5".
+type RateLimitRule struct {
+    Requests int
+    Period   time.Duration
+    Burst    int
+}
+
+// Load reads the configuration from the environment, falling back to defaults
+// that match docker-compose.yml.
+func Load() Config {
+    dsn := os.Getenv("DATABASE_DSN")
+    if dsn == "" {
+        dsn = fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+            getEnv("DB_HOST", "postgres"),
+            getEnv("DB_USER", "postgres"),
+            getEnv("DB_PASSWORD", "postgres"),
+            getEnv("DB_NAME", "bank"),
+            getEnv("DB_PORT", "5432"),
+        )
+    }
+
+    return Config{
+        DatabaseDSN:         dsn,
+        BootstrapAdminEmail: getEnv("BOOTSTRAP_ADMIN_EMAIL", ""),
+        TrustedProxies:      getEnvList("TRUSTED_PROXIES"),
+        MFA: MFAConfig{
+            Issuer:            getEnv("MFA_ISSUER", "BankXIT"),
+            ChallengeTTL:      getEnvDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+            StepUpThreshold:   getEnvFloat("MFA_STEP_UP_THRESHOLD", 1000),
+            RecoveryCodeCount: getEnvInt("MFA_RECOVERY_CODES", 10),
+        },
+        Mail: MailConfig{
+            Driver:       getEnv("MAIL_DRIVER", "log"),
+            From:         getEnv("MAIL_FROM", "no-reply@bankxit.local"),
+            SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+            SMTPPort:     getEnv("SMTP_PORT", "587"),
+            SMTPUser:     getEnv("SMTP_USER", ""),
+            SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+            Dir:          getEnv("MAIL_DIR", "mail"),
+        },
+        Email: EmailConfig{
+            BaseURL:         getEnv("APP_BASE_URL", "http://localhost:8080"),
+            VerificationTTL: getEnvDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+            ResetTTL:        getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+            RequireVerified: getEnvBool("REQUIRE_VERIFIED_EMAIL", true),
+        },
+        AccountNumbers: AccountNumberConfig{
+            Length:       getEnvInt("ACCOUNT_NUMBER_LENGTH", 10),
+            IBANEnabled:  getEnvBool("IBAN_ENABLED", false),
+            IBANCountry:  getEnv("IBAN_COUNTRY", "DE"),
+            IBANBankCode: getEnv("IBAN_BANK_CODE", "10010010"),
+        },
+        Limits: LimitsConfig{
+            SingleMax:           getEnvFloat("LIMIT_SINGLE_MAX", 10000),
+            DailyMax:            getEnvFloat("LIMIT_DAILY_MAX", 20000),
+            MonthlyMax:          getEnvFloat("LIMIT_MONTHLY_MAX", 200000),
+            HourlyTransferCount: getEnvInt("LIMIT_HOURLY_TRANSFERS", 20),
+        },
+        Scheduler: SchedulerConfig{
+            Enabled:     getEnvBool("SCHEDULER_ENABLED", true),
+            Interval:    getEnvInterval("SCHEDULER_INTERVAL", time.Minute),
+            BatchSize:   getEnvInt("SCHEDULER_BATCH_SIZE", 100),
+            MaxAttempts: getEnvInt("SCHEDULER_MAX_ATTEMPTS", 3),
+            RetryDelay:  getEnvDuration("SCHEDULER_RETRY_DELAY", time.Hour),
+        },
+        Webhooks: WebhookConfig{
+            Enabled:     getEnvBool("WEBHOOKS_ENABLED", true),
+            Interval:    getEnvInterval("WEBHOOKS_INTERVAL", 5*time.Second),
+            BatchSize:   getEnvInt("WEBHOOKS_BATCH_SIZE", 100),
+            Timeout:     getEnvDuration("WEBHOOKS_TIMEOUT", 10*time.Second),
+            MaxAttempts: getEnvInt("WEBHOOKS_MAX_ATTEMPTS", 8),
+            BaseBackoff: getEnvDuration("WEBHOOKS_BASE_BACKOFF", 30*time.Second),
+            MaxBackoff:  getEnvDuration("WEBHOOKS_MAX_BACKOFF", 6*time.Hour),
+        },
+        Log: LogConfig{
+            Level:  getEnv("LOG_LEVEL", "info"),
+            Format: getEnv("LOG_FORMAT", "json"),
+        },
+        Metrics: MetricsConfig{
+            Token: getEnv("METRICS_TOKEN", ""),
+        },
+        Tracing: TracingConfig{
+            Exporter:     getEnv("TRACING_EXPORTER", "none"),
+            File:         getEnv("TRACING_FILE", "traces.jsonl"),
+            OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", "http://localhost:4318/v1/traces"),
+            ServiceName:  getEnv("TRACING_SERVICE_NAME", "bank-api"),
+            SampleRatio:  getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+        },
+        RateLimit: RateLimitConfig{
+            Enabled: getEnvBool("RATE_LIMIT_ENABLED", true),
+            Store:   getEnv("RATE_LIMIT_STORE", "memory"),
+            Default: getEnvRule("RATE_LIMIT_DEFAULT", RateLimitRule{Requests: 300, Period: time.Minute, Burst: 100}),
+            Auth:    getEnvRule("RATE_LIMIT_AUTH", RateLimitRule{Requests: 10, Period: time.Minute, Burst: 5}),
+            Money:   getEnvRule("RATE_LIMIT_MONEY", RateLimitRule{Requests: 30, Period: time.Minute, Burst: 10}),
+        },
+        GRPC: GRPCConfig{
+            Enabled:          getEnvBool("GRPC_ENABLED", true),
+            Addr:             getEnv("GRPC_ADDR", ":9090"),
+            Reflection:       getEnvBool("GRPC_REFLECTION", true),
+            FeedPollInterval: getEnvInterval("GRPC_FEED_POLL_INTERVAL", time.Second),
+        },
+        Stream: StreamConfig{
+            PollInterval: getEnvInterval("STREAM_POLL_INTERVAL", 500*time.Millisecond),
+            Heartbeat:    getEnvInterval("STREAM_HEARTBEAT", 15*time.Second),
+            Buffer:       getEnvInt("STREAM_BUFFER", 64),
+        },
+        Approval: ApprovalConfig{
+            TTL:               getEnvDuration("APPROVAL_TTL", 72*time.Hour),
+            TransferThreshold: getEnvFloat("APPROVAL_TRANSFER_THRESHOLD", 10000),
+            ExpiryInterval:    getEnvInterval("APPROVAL_EXPIRY_INTERVAL", 5*time.Minute),
+        },
+        Underwriting: UnderwritingConfig{
+            AutoDecide:          getEnvBool("UNDERWRITING_AUTO_DECIDE", true),
+            LookbackDays:        getEnvInt("UNDERWRITING_LOOKBACK_DAYS", 90),
+            MinAccountAgeDays:   getEnvInt("UNDERWRITING_MIN_ACCOUNT_AGE_DAYS", 30),
+            MaxPrincipal:        getEnvFloat("UNDERWRITING_MAX_PRINCIPAL", 50000),
+            MaxTermMonths:       getEnvInt("UNDERWRITING_MAX_TERM_MONTHS", 84),
+            AutoApproveLimit:    getEnvFloat("UNDERWRITING_AUTO_APPROVE_LIMIT", 10000),
+            MinAverageBalance:   getEnvFloat("UNDERWRITING_MIN_AVERAGE_BALANCE", 100),
+            MaxPaymentToInflow:  getEnvFloat("UNDERWRITING_MAX_PAYMENT_TO_INFLOW", 0.35),
+            MaxExposureToInflow: getEnvFloat("UNDERWRITING_MAX_EXPOSURE_TO_INFLOW", 12),
+            BaseRate:            getEnvFloat("UNDERWRITING_BASE_RATE", 6),
+            LongTermPremium:     getEnvFloat("UNDERWRITING_LONG_TERM_PREMIUM", 1),
+            MaxRate:             getEnvFloat("UNDERWRITING_MAX_RATE", 24),
+        },
+        Loan: LoanConfig{
+            DelinquencyInterval: getEnvInterval("LOAN_DELINQUENCY_INTERVAL", 24*time.Hour),
+            DefaultAfterDays:    getEnvInt("LOAN_DEFAULT_AFTER_DAYS", 90),
+            RepaymentOrder:      strings.Split(getEnv("LOAN_REPAYMENT_ORDER", "fees,interest,principal"), ","),
+            Prepayment:          getEnv("LOAN_PREPAYMENT", "shorten_term"),
+            CollectionInterval:  getEnvInterval("LOAN_COLLECTION_INTERVAL", time.Hour),
+        },
+        Overdraft: OverdraftConfig{
+            Products:         strings.Split(getEnv("OVERDRAFT_PRODUCTS", "checking,standard"), ","),
+            MaxLimit:         getEnvFloat("OVERDRAFT_MAX_LIMIT", 5000),
+            InterestRate:     getEnvFloat("OVERDRAFT_INTEREST_RATE", 12),
+            Fee:              getEnvFloat("OVERDRAFT_FEE", 0),
+            InterestInterval: getEnvInterval("OVERDRAFT_INTEREST_INTERVAL", time.Hour),
+        },
+        Holds: HoldConfig{
+            DefaultTTL:     getEnvDuration("HOLD_TTL", 7*24*time.Hour),
+            MaxTTL:         getEnvDuration("HOLD_MAX_TTL", 30*24*time.Hour),
+            ExpiryInterval: getEnvInterval("HOLD_EXPIRY_INTERVAL", 5*time.Minute),
+        },
+        API: APIConfig{
+            LegacyRoutes: getEnvBool("API_LEGACY_ROUTES", true),
+            DeprecatedAt: getEnvDate("API_LEGACY_DEPRECATED_AT", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)),
+            Sunset:       getEnvDate("API_LEGACY_SUNSET", time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)),
+        },
+    }
+}
+
+func getEnv(key, fallback string) string {
+    if v, ok := os.LookupEnv(key); ok && v != "" {
+        return v
+    }
+    return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+    if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+        return v
+    }
+    return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+    if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+        return v
+    }
+    return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+    if v, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+        return v
+    }
+    return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+    if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+        return v
+    }
+    return fallback
+}
+
+// getEnvInterval is getEnvDuration for ticker intervals, which must be
+// positive: zero or negative values fall back to the default.
+func getEnvInterval(key string, fallback time.Duration) time.Duration {
+    if v := getEnvDuration(key, fallback); v > 0 {
+        return v
+    }
+    return fallback
+}
+
+// getEnvList splits a comma-separated value, dropping empty entries. It
+// returns nil when the variable is unset or empty.
+func getEnvList(key string) []string {
+    var list []string
+    for _, v := range strings.Split(os.Getenv(key), ",") {
+        if v = strings.TrimSpace(v); v != "" {
+            list = append(list, v)
+        }
+    }
+    return list
+}
+
+// getEnvDate parses a "YYYY-MM-DD" date (midnight UTC).
+func getEnvDate(key string, fallback time.Time) time.Time {
+    if v, err := time.Parse("2006-01-02", os.Getenv(key)); err == nil {
+        return v
+    }
+    return fallback
+}
+
+// getEnvRule parses "requests/period[,burst]", e.g. "10/1m,5". The burst
+// defaults to the number of requests.
+func getEnvRule(key string, fallback RateLimitRule) RateLimitRule {
+    v := os.Getenv(key)
+    if v == "" {
+        return fallback
+    }
+    spec, burstStr, hasBurst := strings.Cut(v, ",")
+    reqStr, periodStr, ok := strings.Cut(spec, "/")
+    if !ok {
+        return fallback
+    }
+    requests, err := strconv.Atoi(strings.TrimSpace(reqStr))
+    if err != nil || requests <= 0 {
+        return fallback
+    }
+    period, err := time.ParseDuration(strings.TrimSpace(periodStr))
+    if err != nil || period <= 0 {
+        return fallback
+    }
+    rule := RateLimitRule{Requests: requests, Period: period, Burst: requests}
+    if hasBurst {
+        burst, err := strconv.Atoi(strings.TrimSpace(burstStr))
+        if err != nil || burst <= 0 {
+            return fallback
+        }
+        rule.Burst = burst
+    }
+    return rule
+}
@@ -0,0 +1,38 @@
+// docs/docs.go
+package docs
+
+import (
+    _ "embed"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+)
+
+// Spec is the OpenAPI 3 document for the HTTP API. Keep it in step with routes.go;
+// the route coverage test in package main fails when a route is missing.
+//
+//go:embed openapi.json
+var Spec []byte
+
+//go:embed index.html
+var indexHTML []byte
+
+// docsCSP keeps the /docs page to its inline bootstrap script (by hash) and the
+// exact Swagger UI files index.html loads. Update it whenever index.html changes.
+const docsCSP = "default-src 'none'; " +
+    "script-src 'sha256-zDjRLLTO5WJ5kcorJlBUzqtmmn7I9If1YD6AkctnkUY=' https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js; " +
+    "style-src 'unsafe-inline' https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css; " +
+    "img-src 'self' data:; connect-src 'self'"
+
+// Register serves the spec at /openapi.json and a Swagger UI page for it at /docs.
+func Register(router gin.IRoutes) {
+    router.GET("/openapi.json", func(c *gin.Context) {
+        c.Data(http.StatusOK, "application/json", Spec)
+    })
+    router.GET("/docs", func(c *gin.Context) {
+        // Scripts only from this page and the pinned Swagger UI release.
+        c.Header("Content-Security-Policy", docsCSP)
+        c.Header("Referrer-Policy", "no-referrer")
+        c.Data(http.StatusOK, "text/html; charset=utf-8", indexHTML)
+    })
+}
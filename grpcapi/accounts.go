@@ -0,0 +1,175 @@
+// grpcapi/accounts.go
+package grpcapi
+
+import (
+    "context"
+    "errors"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    bankv1 "github.com/bhushangupta162/bank_management/proto/bank/v1"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// accountServer mirrors the account and money routes in handlers/account.go.
+type accountServer struct {
+    bankv1.UnimplementedAccountServiceServer
+    db  *gorm.DB
+    cfg config.Config
+}
+
+func (s *accountServer) CreateAccount(ctx context.Context, req *bankv1.CreateAccountRequest) (*bankv1.Account, error) {
+    account, err := services.CreateAccount(s.db.WithContext(ctx), s.cfg.AccountNumbers, uint(req.GetUserId()), req.GetProduct(), req.GetCurrency())
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "account", account.ID, nil, account)
+    return accountToProto(account), nil
+}
+
+func (s *accountServer) GetAccount(ctx context.Context, req *bankv1.GetAccountRequest) (*bankv1.Account, error) {
+    var account models.Account
+    if err := services.FindAccountByRef(s.db.WithContext(ctx), req.GetRef(), s.cfg.AccountNumbers, &account); err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil, serviceError(ctx, &services.Error{Kind: services.ErrNotFound, Message: "Account not found"})
+        }
+        return nil, serviceError(ctx, err)
+    }
+    return accountToProto(&account), nil
+}
+
+func (s *accountServer) Deposit(ctx context.Context, req *bankv1.MoneyRequest) (*bankv1.MoneyResponse, error) {
+    result, err := services.Deposit(s.db.WithContext(ctx), s.cfg.Email, uint(req.GetAccountId()), req.GetAmount())
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "account", result.Account.ID, result.Before, result.Account)
+    return &bankv1.MoneyResponse{Account: accountToProto(&result.Account), Transaction: transactionToProto(&result.Transaction)}, nil
+}
+
+func (s *accountServer) Withdraw(ctx context.Context, req *bankv1.MoneyRequest) (*bankv1.MoneyResponse, error) {
+    result, err := services.Withdraw(s.db.WithContext(ctx), s.cfg.Email, s.cfg.Limits, uint(req.GetAccountId()), req.GetAmount())
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "account", result.Account.ID, result.Before, result.Account)
+    resp := &bankv1.MoneyResponse{Account: accountToProto(&result.Account), Transaction: transactionToProto(&result.Transaction)}
+    if result.OverdraftFee != nil {
+        resp.OverdraftFee = transactionToProto(result.OverdraftFee)
+    }
+    return resp, nil
+}
+
+func (s *accountServer) Transfer(ctx context.Context, req *bankv1.TransferRequest) (*bankv1.TransferResponse, error) {
+    db := s.db.WithContext(ctx)
+    fromID, err := services.ResolveAccountID(db, uint(req.GetFromAccountId()), req.GetFromAccount(), s.cfg.AccountNumbers)
+    if err != nil {
+        return nil, accountRefError(ctx, "Source", err)
+    }
+    toID, err := services.ResolveAccountID(db, uint(req.GetToAccountId()), req.GetToAccount(), s.cfg.AccountNumbers)
+    if err != nil {
+        return nil, accountRefError(ctx, "Destination", err)
+    }
+
+    // Verified email and step-up authentication, checked against the source account's owner
+    source, err := services.AuthorizeTransfer(db, s.cfg.Email, s.cfg.MFA, fromID, req.GetAmount(), req.GetMfaCode())
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    transfer := services.TransferRequest{
+        FromAccountID: fromID,
+        ToAccountID:   toID,
+        Amount:        req.GetAmount(),
+    }
+    if services.TransferNeedsApproval(s.cfg.Approval, req.GetAmount()) {
+        action, err := services.ProposeTransfer(db, s.cfg.Approval, transfer, source.UserID)
+        if err != nil {
+            return nil, serviceError(ctx, err)
+        }
+        setAudit(ctx, "pending_action", action.ID, nil, action)
+        return &bankv1.TransferResponse{PendingAction: pendingActionToProto(action)}, nil
+    }
+    result, err := services.Transfer(db, s.cfg.Limits, transfer)
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "account", source.ID, *source, result.FromAccount)
+    result.RecordMetrics()
+
+    resp := &bankv1.TransferResponse{
+        FromAccount: accountToProto(&result.FromAccount),
+        ToAccount:   accountToProto(&result.ToAccount),
+        OutTx:       transactionToProto(&result.OutTx),
+        InTx:        transactionToProto(&result.InTx),
+    }
+    if result.OverdraftFee != nil {
+        resp.OverdraftFee = transactionToProto(result.OverdraftFee)
+    }
+    return resp, nil
+}
+
+func (s *accountServer) SetOverdraft(ctx context.Context, req *bankv1.SetOverdraftRequest) (*bankv1.Account, error) {
+    update, err := services.SetOverdraft(s.db.WithContext(ctx), s.cfg.Overdraft, uint(req.GetAccountId()), services.OverdraftTerms{
+        Limit:        req.GetLimit(),
+        InterestRate: req.InterestRate,
+        Fee:          req.Fee,
+    })
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "account", update.Account.ID, update.Before, update.Account)
+    return accountToProto(&update.Account), nil
+}
+
+func (s *accountServer) CreateHold(ctx context.Context, req *bankv1.CreateHoldRequest) (*bankv1.HoldResponse, error) {
+    hold := services.HoldRequest{
+        AccountID:   uint(req.GetAccountId()),
+        Amount:      req.GetAmount(),
+        Description: req.GetDescription(),
+        Reference:   req.GetReference(),
+    }
+    if req.GetExpiresAt() != nil {
+        expiresAt := req.GetExpiresAt().AsTime()
+        hold.ExpiresAt = &expiresAt
+    }
+    userID, _ := currentUserID(ctx)
+    result, err := services.CreateHold(s.db.WithContext(ctx), s.cfg.Email, s.cfg.Limits, s.cfg.Holds, hold, userID)
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "hold", result.Hold.ID, nil, result.Hold)
+    return holdResultToProto(result), nil
+}
+
+func (s *accountServer) CaptureHold(ctx context.Context, req *bankv1.CaptureHoldRequest) (*bankv1.HoldResponse, error) {
+    userID, _ := currentUserID(ctx)
+    result, err := services.CaptureHold(s.db.WithContext(ctx), uint(req.GetId()), req.GetAmount(), userID)
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "account", result.Account.ID, result.Before, result.Account)
+    return holdResultToProto(result), nil
+}
+
+func (s *accountServer) ReleaseHold(ctx context.Context, req *bankv1.ReleaseHoldRequest) (*bankv1.HoldResponse, error) {
+    userID, _ := currentUserID(ctx)
+    result, err := services.ReleaseHold(s.db.WithContext(ctx), uint(req.GetId()), userID)
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "account", result.Account.ID, result.Before, result.Account)
+    return holdResultToProto(result), nil
+}
+
+// accountRefError answers a failed account lookup from services.ResolveAccountID.
+func accountRefError(ctx context.Context, which string, err error) error {
+    if errors.Is(err, services.ErrInvalidAccountRef) {
+        return serviceError(ctx, &services.Error{Kind: services.ErrInvalid, Message: which + " account is missing or invalid"})
+    }
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return serviceError(ctx, &services.Error{Kind: services.ErrNotFound, Message: which + " account not found"})
+    }
+    return serviceError(ctx, err)
+}
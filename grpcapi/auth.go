@@ -0,0 +1,38 @@
+// grpcapi/auth.go
+package grpcapi
+
+import (
+    "context"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    bankv1 "github.com/bhushangupta162/bank_management/proto/bank/v1"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// authServer mirrors POST /login and POST /login/mfa.
+type authServer struct {
+    bankv1.UnimplementedAuthServiceServer
+    db  *gorm.DB
+    cfg config.Config
+}
+
+func (s *authServer) Login(ctx context.Context, req *bankv1.LoginRequest) (*bankv1.LoginResponse, error) {
+    result, err := services.Login(s.db.WithContext(ctx), s.cfg.MFA, req.GetEmail(), req.GetPassword())
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    if result.MFAToken != "" {
+        return &bankv1.LoginResponse{MfaRequired: true, MfaToken: result.MFAToken}, nil
+    }
+    return &bankv1.LoginResponse{Token: result.Token}, nil
+}
+
+func (s *authServer) LoginMFA(ctx context.Context, req *bankv1.LoginMFARequest) (*bankv1.LoginResponse, error) {
+    token, err := services.LoginMFA(s.db.WithContext(ctx), req.GetMfaToken(), req.GetCode(), req.GetRecoveryCode())
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    return &bankv1.LoginResponse{Token: token}, nil
+}
@@ -0,0 +1,153 @@
+// grpcapi/convert.go
+package grpcapi
+
+import (
+    "time"
+
+    "google.golang.org/protobuf/types/known/timestamppb"
+
+    "github.com/bhushangupta162/bank_management/models"
+    bankv1 "github.com/bhushangupta162/bank_management/proto/bank/v1"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+    if t == nil {
+        return nil
+    }
+    return timestamppb.New(*t)
+}
+
+func stringOrEmpty(s *string) string {
+    if s == nil {
+        return ""
+    }
+    return *s
+}
+
+func accountToProto(a *models.Account) *bankv1.Account {
+    return &bankv1.Account{
+        Id:            uint64(a.ID),
+        UserId:        uint64(a.UserID),
+        AccountNumber: stringOrEmpty(a.AccountNumber),
+        Iban:          stringOrEmpty(a.IBAN),
+        Product:       a.Product,
+        Currency:      a.Currency,
+        Balance:       a.Balance,
+        Status:        a.Status,
+        CreatedAt:     timestamppb.New(a.CreatedAt),
+        UpdatedAt:     timestamppb.New(a.UpdatedAt),
+        ClosedAt:      timestampOrNil(a.ClosedAt),
+
+        OverdraftLimit:   a.OverdraftLimit,
+        OverdraftRate:    a.OverdraftRate,
+        OverdraftFee:     a.OverdraftFee,
+        AvailableBalance: a.AvailableBalance(),
+        HeldAmount:       a.HeldAmount,
+        LedgerBalance:    a.Balance,
+    }
+}
+
+func transactionToProto(t *models.Transaction) *bankv1.Transaction {
+    return &bankv1.Transaction{
+        Id:              uint64(t.ID),
+        AccountId:       uint64(t.AccountID),
+        TransactionType: t.TransactionType,
+        Amount:          t.Amount,
+        Description:     t.Description,
+        CreatedAt:       timestamppb.New(t.CreatedAt),
+        BalanceAfter:    t.BalanceAfter,
+    }
+}
+
+func loanToProto(l *models.Loan) *bankv1.Loan {
+    pb := &bankv1.Loan{
+        Id:                   uint64(l.ID),
+        UserId:               uint64(l.UserID),
+        Principal:            l.Principal,
+        InterestRate:         l.InterestRate,
+        TermMonths:           int32(l.TermMonths),
+        Status:               l.Status,
+        OutstandingBalance:   l.OutstandingBalance,
+        DecidedAt:            timestampOrNil(l.DecidedAt),
+        CreatedAt:            timestamppb.New(l.CreatedAt),
+        UpdatedAt:            timestamppb.New(l.UpdatedAt),
+        UnderwritingDecision: l.UnderwritingDecision,
+        CreditScore:          int32(l.CreditScore),
+        ReasonCodes:          l.Reasons(),
+        ProductCode:          l.ProductCode,
+        OriginationFee:       l.OriginationFee,
+        DisbursedAt:          timestampOrNil(l.DisbursedAt),
+        InstallmentAmount:    l.InstallmentAmount,
+        DaysPastDue:          int32(l.DaysPastDue),
+        DelinquencyBucket:    l.DelinquencyBucket,
+        FeesOutstanding:      l.FeesOutstanding,
+    }
+    if l.DecidedByID != nil {
+        pb.DecidedById = uint64(*l.DecidedByID)
+    }
+    if l.ProductID != nil {
+        pb.ProductId = uint64(*l.ProductID)
+    }
+    if l.RepaymentAccountID != nil {
+        pb.RepaymentAccountId = uint64(*l.RepaymentAccountID)
+    }
+    return pb
+}
+
+func holdToProto(h *models.Hold) *bankv1.Hold {
+    pb := &bankv1.Hold{
+        Id:             uint64(h.ID),
+        AccountId:      uint64(h.AccountID),
+        Amount:         h.Amount,
+        Description:    h.Description,
+        Reference:      h.Reference,
+        Status:         h.Status,
+        ExpiresAt:      timestamppb.New(h.ExpiresAt),
+        ClosedAt:       timestampOrNil(h.ClosedAt),
+        CapturedAmount: h.CapturedAmount,
+        CreatedAt:      timestamppb.New(h.CreatedAt),
+    }
+    if h.TransactionID != nil {
+        pb.TransactionId = uint64(*h.TransactionID)
+    }
+    return pb
+}
+
+func holdResultToProto(r *services.HoldResult) *bankv1.HoldResponse {
+    resp := &bankv1.HoldResponse{Account: accountToProto(&r.Account), Hold: holdToProto(&r.Hold)}
+    if r.Transaction != nil {
+        resp.Transaction = transactionToProto(r.Transaction)
+    }
+    if r.OverdraftFee != nil {
+        resp.OverdraftFee = transactionToProto(r.OverdraftFee)
+    }
+    return resp
+}
+
+func payoffToProto(q *services.PayoffQuote) *bankv1.PayoffQuote {
+    return &bankv1.PayoffQuote{
+        LoanId:            uint64(q.LoanID),
+        AsOf:              timestamppb.New(q.AsOf),
+        Principal:         q.Principal,
+        AccruedInterest:   q.AccruedInterest,
+        Fees:              q.Fees,
+        PrepaymentPenalty: q.PrepaymentPenalty,
+        Total:             q.Total,
+    }
+}
+
+func pendingActionToProto(a *models.PendingAction) *bankv1.PendingAction {
+    return &bankv1.PendingAction{
+        Id:         uint64(a.ID),
+        Kind:       a.Kind,
+        EntityType: a.EntityType,
+        EntityId:   uint64(a.EntityID),
+        Payload:    a.Payload,
+        Reason:     a.Reason,
+        Status:     a.Status,
+        MakerId:    uint64(a.MakerID),
+        ExpiresAt:  timestamppb.New(a.ExpiresAt),
+        CreatedAt:  timestamppb.New(a.CreatedAt),
+    }
+}
@@ -0,0 +1,50 @@
+// grpcapi/errors.go
+package grpcapi
+
+import (
+    "context"
+    "errors"
+
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// serviceError turns an error from the services package into a gRPC status,
+// like handlers.serviceError does for HTTP. Unexpected errors become
+// codes.Internal without leaking details.
+func serviceError(ctx context.Context, err error) error {
+    var limitErr *services.LimitError
+    if errors.As(err, &limitErr) {
+        return status.Error(codes.ResourceExhausted, limitErr.Error())
+    }
+    if errors.Is(err, services.ErrInvalidAccountRef) {
+        return status.Error(codes.InvalidArgument, "Invalid account ID or number")
+    }
+
+    var svcErr *services.Error
+    if !errors.As(err, &svcErr) {
+        return internalError(ctx, "Internal error", err)
+    }
+    switch {
+    case errors.Is(svcErr, services.ErrUnauthenticated):
+        return status.Error(codes.Unauthenticated, svcErr.Message)
+    case errors.Is(svcErr, services.ErrNotFound):
+        return status.Error(codes.NotFound, svcErr.Message)
+    case errors.Is(svcErr, services.ErrForbidden):
+        return status.Error(codes.PermissionDenied, svcErr.Message)
+    case errors.Is(svcErr, services.ErrConflict):
+        return status.Error(codes.FailedPrecondition, svcErr.Message)
+    default:
+        return status.Error(codes.InvalidArgument, svcErr.Message)
+    }
+}
+
+// internalError logs an unexpected failure and returns codes.Internal with a
+// message that does not expose the cause.
+func internalError(ctx context.Context, message string, err error) error {
+    logging.FromContext(ctx).Error(message, "error", err)
+    return status.Error(codes.Internal, message)
+}
@@ -0,0 +1,266 @@
+// grpcapi/interceptors.go
+package grpcapi
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+    "net/http"
+    "strings"
+    "time"
+
+    "go.opentelemetry.io/otel/trace"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/peer"
+    "google.golang.org/grpc/status"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/utils"
+)
+
+type ctxKey int
+
+const (
+    userIDKey ctxKey = iota
+    requestIDKey
+    auditKey
+)
+
+// requestIDMetadata carries the correlation ID, like the X-Request-ID header.
+const requestIDMetadata = "x-request-id"
+
+// currentUserID returns the user the auth interceptor authenticated.
+func currentUserID(ctx context.Context) (uint, bool) {
+    id, ok := ctx.Value(userIDKey).(uint)
+    return id, ok && id > 0
+}
+
+// withRequest honors an incoming x-request-id or creates one, echoes it in the
+// response headers and attaches a logger carrying it (and the trace ID) to ctx.
+func withRequest(ctx context.Context, logger *slog.Logger, method string) context.Context {
+    var incoming string
+    if md, ok := metadata.FromIncomingContext(ctx); ok {
+        if v := md.Get(requestIDMetadata); len(v) > 0 {
+            incoming = v[0]
+        }
+    }
+    id := logging.RequestIDFrom(incoming)
+    _ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, id))
+
+    reqLogger := logger.With("request_id", id, "grpc_method", method)
+    if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+        reqLogger = reqLogger.With("trace_id", sc.TraceID().String())
+    }
+    ctx = context.WithValue(ctx, requestIDKey, id)
+    return logging.WithContext(ctx, reqLogger)
+}
+
+// logRPC writes one structured line per call, like logging.AccessLog.
+func logRPC(ctx context.Context, start time.Time, err error) {
+    code := status.Code(err)
+    level := slog.LevelInfo
+    switch code {
+    case codes.OK, codes.Canceled:
+    case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+        level = slog.LevelError
+    default:
+        level = slog.LevelWarn
+    }
+    logging.FromContext(ctx).Log(ctx, level, "grpc request",
+        "code", code.String(),
+        "latency_ms", float64(time.Since(start).Microseconds())/1000,
+    )
+}
+
+func logUnary(logger *slog.Logger) grpc.UnaryServerInterceptor {
+    return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+        start := time.Now()
+        ctx = withRequest(ctx, logger, info.FullMethod)
+        resp, err := handler(ctx, req)
+        logRPC(ctx, start, err)
+        return resp, err
+    }
+}
+
+func logStream(logger *slog.Logger) grpc.StreamServerInterceptor {
+    return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+        start := time.Now()
+        ctx := withRequest(ss.Context(), logger, info.FullMethod)
+        err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+        logRPC(ctx, start, err)
+        return err
+    }
+}
+
+// recoverUnary turns a panic into codes.Internal, like logging.Recovery.
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            logging.FromContext(ctx).Error("panic recovered", "panic", fmt.Sprint(r))
+            err = status.Error(codes.Internal, "Internal error")
+        }
+    }()
+    return handler(ctx, req)
+}
+
+func recoverStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            logging.FromContext(ss.Context()).Error("panic recovered", "panic", fmt.Sprint(r))
+            err = status.Error(codes.Internal, "Internal error")
+        }
+    }()
+    return handler(srv, ss)
+}
+
+// authenticate does what AuthMiddleware and RequireRole do for the REST API:
+// it checks the bearer token in the "authorization" metadata and the caller's role.
+func authenticate(ctx context.Context, db *gorm.DB, fullMethod string) (context.Context, error) {
+    policy := policyFor(fullMethod)
+    if policy.public {
+        return ctx, nil
+    }
+
+    var header string
+    if md, ok := metadata.FromIncomingContext(ctx); ok {
+        if v := md.Get("authorization"); len(v) > 0 {
+            header = v[0]
+        }
+    }
+    token := strings.TrimPrefix(header, "Bearer ")
+    if token == "" {
+        return nil, status.Error(codes.Unauthenticated, "Missing authorization metadata")
+    }
+    // MFA challenge tokens are rejected here.
+    userID, err := utils.ParseToken(token, utils.TokenTypeAccess)
+    if err != nil {
+        return nil, status.Error(codes.Unauthenticated, "Invalid token")
+    }
+
+    if len(policy.roles) > 0 {
+        var user models.User
+        if err := db.WithContext(ctx).Select("id", "role").First(&user, userID).Error; err != nil {
+            return nil, status.Error(codes.Unauthenticated, "User not found")
+        }
+        allowed := false
+        for _, role := range policy.roles {
+            allowed = allowed || user.Role == role
+        }
+        if !allowed {
+            return nil, status.Error(codes.PermissionDenied, "Insufficient permissions")
+        }
+    }
+
+    if rec, ok := ctx.Value(auditKey).(*auditRecord); ok {
+        rec.actorID = &userID
+    }
+    ctx = context.WithValue(ctx, userIDKey, userID)
+    return logging.WithContext(ctx, logging.FromContext(ctx).With("user_id", userID)), nil
+}
+
+func authUnary(db *gorm.DB) grpc.UnaryServerInterceptor {
+    return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+        ctx, err := authenticate(ctx, db, info.FullMethod)
+        if err != nil {
+            return nil, err
+        }
+        return handler(ctx, req)
+    }
+}
+
+func authStream(db *gorm.DB) grpc.StreamServerInterceptor {
+    return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+        ctx, err := authenticate(ss.Context(), db, info.FullMethod)
+        if err != nil {
+            return err
+        }
+        return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+    }
+}
+
+// auditRecord is filled in by the service methods, like audit.SetEntity and
+// audit.SetChange in the REST handlers.
+type auditRecord struct {
+    actorID    *uint // Set by authenticate
+    entityType string
+    entityID   string
+    before     string
+    after      string
+}
+
+// setAudit names the entity a call acted on and stores before/after snapshots.
+// Pass nil for before when the entity was created.
+func setAudit(ctx context.Context, entityType string, id uint, before, after interface{}) {
+    if rec, ok := ctx.Value(auditKey).(*auditRecord); ok {
+        rec.entityType = entityType
+        rec.entityID = fmt.Sprint(id)
+        rec.before = audit.Snapshot(before)
+        rec.after = audit.Snapshot(after)
+    }
+}
+
+// auditUnary appends an audit entry for every state-changing call after it
+// ran, whatever the outcome. The action is "GRPC <full method>".
+func auditUnary(db *gorm.DB) grpc.UnaryServerInterceptor {
+    return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+        if !policyFor(info.FullMethod).audited {
+            return handler(ctx, req)
+        }
+        rec := &auditRecord{}
+        resp, err := handler(context.WithValue(ctx, auditKey, rec), req)
+
+        entry := models.AuditEntry{
+            ActorID:    rec.actorID,
+            Action:     "GRPC " + info.FullMethod,
+            EntityType: rec.entityType,
+            EntityID:   rec.entityID,
+            Before:     rec.before,
+            After:      rec.after,
+            StatusCode: httpStatus(status.Code(err)),
+        }
+        if p, ok := peer.FromContext(ctx); ok {
+            entry.IP = p.Addr.String()
+        }
+        entry.RequestID, _ = ctx.Value(requestIDKey).(string)
+        entry.Diff = audit.Diff(entry.Before, entry.After)
+
+        if appendErr := audit.Append(db.WithContext(ctx), &entry); appendErr != nil {
+            logging.FromContext(ctx).Error("could not record audit entry", "action", entry.Action, "error", appendErr)
+        }
+        return resp, err
+    }
+}
+
+// httpStatus maps a gRPC code to the HTTP status the REST API answers with in
+// the same situation, so audit entries from both transports can be filtered alike.
+func httpStatus(code codes.Code) int {
+    switch code {
+    case codes.OK:
+        return http.StatusOK
+    case codes.InvalidArgument, codes.OutOfRange:
+        return http.StatusBadRequest
+    case codes.Unauthenticated:
+        return http.StatusUnauthorized
+    case codes.PermissionDenied, codes.ResourceExhausted:
+        return http.StatusForbidden
+    case codes.NotFound:
+        return http.StatusNotFound
+    case codes.FailedPrecondition, codes.AlreadyExists, codes.Aborted:
+        return http.StatusConflict
+    default:
+        return http.StatusInternalServerError
+    }
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+    grpc.ServerStream
+    ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,117 @@
+// grpcapi/loans.go
+package grpcapi
+
+import (
+    "context"
+    "time"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    bankv1 "github.com/bhushangupta162/bank_management/proto/bank/v1"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// loanServer mirrors the loan routes in handlers/loan.go.
+type loanServer struct {
+    bankv1.UnimplementedLoanServiceServer
+    db           *gorm.DB
+    approval     config.ApprovalConfig
+    underwriting config.UnderwritingConfig
+    loan         config.LoanConfig
+}
+
+func (s *loanServer) ApplyLoan(ctx context.Context, req *bankv1.ApplyLoanRequest) (*bankv1.Loan, error) {
+    loan, err := services.ApplyLoan(s.db.WithContext(ctx), s.underwriting, services.LoanApplication{
+        UserID:     uint(req.GetUserId()),
+        ProductID:  uint(req.GetProductId()),
+        Principal:  req.GetPrincipal(),
+        TermMonths: int(req.GetTermMonths()),
+    })
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "loan", loan.ID, nil, loan)
+    return loanToProto(loan), nil
+}
+
+// GetLoan returns a loan to its borrower or to staff.
+func (s *loanServer) GetLoan(ctx context.Context, req *bankv1.GetLoanRequest) (*bankv1.Loan, error) {
+    userID, _ := currentUserID(ctx)
+    loan, err := services.LoanForUser(s.db.WithContext(ctx), userID, uint(req.GetId()))
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    return loanToProto(loan), nil
+}
+
+func (s *loanServer) DecideLoan(ctx context.Context, req *bankv1.DecideLoanRequest) (*bankv1.PendingAction, error) {
+    makerID, _ := currentUserID(ctx)
+    action, err := services.ProposeLoanDecision(s.db.WithContext(ctx), s.approval, uint(req.GetId()), req.GetStatus(), makerID)
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "pending_action", action.ID, nil, action)
+    return pendingActionToProto(action), nil
+}
+
+func (s *loanServer) DisburseLoan(ctx context.Context, req *bankv1.DisburseLoanRequest) (*bankv1.Loan, error) {
+    d, err := services.DisburseLoan(s.db.WithContext(ctx), uint(req.GetId()), uint(req.GetAccountId()))
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "loan", d.Loan.ID, d.Before, d.Loan)
+    return loanToProto(&d.Loan), nil
+}
+
+func (s *loanServer) RepayLoan(ctx context.Context, req *bankv1.RepayLoanRequest) (*bankv1.Loan, error) {
+    repayment, err := services.RepayLoan(s.db.WithContext(ctx), s.loan, services.RepaymentRequest{
+        LoanID:     uint(req.GetId()),
+        Amount:     req.GetAmount(),
+        Prepayment: req.GetPrepayment(),
+    })
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "loan", repayment.Loan.ID, repayment.Before, repayment.Loan)
+    return loanToProto(&repayment.Loan), nil
+}
+
+// GetPayoff quotes a loan's payoff for its borrower or for staff.
+func (s *loanServer) GetPayoff(ctx context.Context, req *bankv1.GetPayoffRequest) (*bankv1.PayoffQuote, error) {
+    db := s.db.WithContext(ctx)
+    userID, _ := currentUserID(ctx)
+    if _, err := services.LoanForUser(db, userID, uint(req.GetId())); err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    asOf := time.Now()
+    if req.GetDate() != nil {
+        asOf = req.GetDate().AsTime()
+    }
+    quote, err := services.LoanPayoff(db, uint(req.GetId()), asOf)
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    return payoffToProto(quote), nil
+}
+
+// SetRepaymentAccount sets or removes a loan's direct debit account, for the
+// borrower or staff.
+func (s *loanServer) SetRepaymentAccount(ctx context.Context, req *bankv1.SetRepaymentAccountRequest) (*bankv1.Loan, error) {
+    db := s.db.WithContext(ctx)
+    userID, _ := currentUserID(ctx)
+    if _, err := services.LoanForUser(db, userID, uint(req.GetId())); err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    var accountID *uint
+    if req.GetAccountId() != 0 {
+        id := uint(req.GetAccountId())
+        accountID = &id
+    }
+    update, err := services.SetRepaymentAccount(db, uint(req.GetId()), accountID)
+    if err != nil {
+        return nil, serviceError(ctx, err)
+    }
+    setAudit(ctx, "loan", update.Loan.ID, update.Before, update.Loan)
+    return loanToProto(&update.Loan), nil
+}
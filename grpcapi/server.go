@@ -0,0 +1,115 @@
+// grpcapi/server.go
+package grpcapi
+
+import (
+    "context"
+    "log/slog"
+    "strings"
+
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/health"
+    healthpb "google.golang.org/grpc/health/grpc_health_v1"
+    "google.golang.org/grpc/reflection"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    bankv1 "github.com/bhushangupta162/bank_management/proto/bank/v1"
+    "github.com/bhushangupta162/bank_management/tracing"
+)
+
+// methodPolicy says who may call a method and whether it changes state.
+type methodPolicy struct {
+    public  bool     // No access token needed
+    roles   []string // The caller needs one of these roles, like RequireRole
+    audited bool     // Recorded in the audit log, like POST/PUT/PATCH/DELETE routes
+}
+
+var staff = []string{models.RoleStaff, models.RoleAdmin}
+
+// policies lists every bank.v1 method. Methods missing here need an access
+// token and are not audited; see policyFor.
+var policies = map[string]methodPolicy{
+    bankv1.AuthService_Login_FullMethodName:    {public: true, audited: true},
+    bankv1.AuthService_LoginMFA_FullMethodName: {public: true, audited: true},
+
+    bankv1.AccountService_CreateAccount_FullMethodName: {audited: true},
+    bankv1.AccountService_GetAccount_FullMethodName:    {},
+    bankv1.AccountService_Deposit_FullMethodName:       {audited: true},
+    bankv1.AccountService_Withdraw_FullMethodName:      {audited: true},
+    bankv1.AccountService_Transfer_FullMethodName:      {audited: true},
+    bankv1.AccountService_SetOverdraft_FullMethodName:  {roles: staff, audited: true},
+    bankv1.AccountService_CreateHold_FullMethodName:    {audited: true},
+    bankv1.AccountService_CaptureHold_FullMethodName:   {audited: true},
+    bankv1.AccountService_ReleaseHold_FullMethodName:   {audited: true},
+
+    bankv1.TransactionService_ListTransactions_FullMethodName:  {},
+    bankv1.TransactionService_WatchTransactions_FullMethodName: {},
+
+    bankv1.LoanService_ApplyLoan_FullMethodName:           {audited: true},
+    bankv1.LoanService_GetLoan_FullMethodName:             {},
+    bankv1.LoanService_DecideLoan_FullMethodName:          {roles: staff, audited: true},
+    bankv1.LoanService_DisburseLoan_FullMethodName:        {roles: staff, audited: true},
+    bankv1.LoanService_RepayLoan_FullMethodName:           {audited: true},
+    bankv1.LoanService_GetPayoff_FullMethodName:           {},
+    bankv1.LoanService_SetRepaymentAccount_FullMethodName: {audited: true},
+}
+
+// policyFor returns the policy of a method. Health checks and reflection are
+// open so load balancers and grpcurl work without a token.
+func policyFor(fullMethod string) methodPolicy {
+    if p, ok := policies[fullMethod]; ok {
+        return p
+    }
+    if strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") || strings.HasPrefix(fullMethod, "/grpc.reflection.") {
+        return methodPolicy{public: true}
+    }
+    return methodPolicy{}
+}
+
+// NewServer builds the gRPC server: tracing, request IDs and logging, panic
+// recovery, JWT auth and auditing, plus every bank.v1 service. It uses the
+// same services package as the REST handlers.
+func NewServer(db *gorm.DB, cfg config.Config, logger *slog.Logger) *grpc.Server {
+    srv := grpc.NewServer(
+        tracing.GRPCServerOption(),
+        grpc.ChainUnaryInterceptor(
+            logUnary(logger),
+            recoverUnary,
+            auditUnary(db), // Before auth, so rejected calls are audited too
+            authUnary(db),
+        ),
+        grpc.ChainStreamInterceptor(
+            logStream(logger),
+            recoverStream,
+            authStream(db),
+        ),
+    )
+
+    bankv1.RegisterAuthServiceServer(srv, &authServer{db: db, cfg: cfg})
+    bankv1.RegisterAccountServiceServer(srv, &accountServer{db: db, cfg: cfg})
+    bankv1.RegisterTransactionServiceServer(srv, &transactionServer{db: db, pollInterval: cfg.GRPC.FeedPollInterval})
+    bankv1.RegisterLoanServiceServer(srv, &loanServer{db: db, approval: cfg.Approval, underwriting: cfg.Underwriting, loan: cfg.Loan})
+
+    healthpb.RegisterHealthServer(srv, health.NewServer())
+    if cfg.GRPC.Reflection {
+        reflection.Register(srv)
+    }
+    return srv
+}
+
+// Shutdown stops accepting calls and waits for running ones. Open
+// WatchTransactions streams never finish on their own, so when ctx expires
+// the remaining calls are cancelled.
+func Shutdown(ctx context.Context, srv *grpc.Server) {
+    done := make(chan struct{})
+    go func() {
+        srv.GracefulStop()
+        close(done)
+    }()
+    select {
+    case <-done:
+    case <-ctx.Done():
+        srv.Stop()
+    }
+}
@@ -0,0 +1,114 @@
+// grpcapi/transactions.go
+package grpcapi
+
+import (
+    "context"
+    "time"
+
+    "google.golang.org/grpc"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/models"
+    bankv1 "github.com/bhushangupta162/bank_management/proto/bank/v1"
+    "github.com/bhushangupta162/bank_management/services"
+    eventstream "github.com/bhushangupta162/bank_management/stream"
+)
+
+// feedBatchSize bounds how many transactions are loaded per query.
+const feedBatchSize = 500
+
+// transactionServer streams an account's transactions, like
+// GET /accounts/:id/transactions but oldest first and without a size limit.
+type transactionServer struct {
+    bankv1.UnimplementedTransactionServiceServer
+    db           *gorm.DB
+    pollInterval time.Duration
+}
+
+func (s *transactionServer) ListTransactions(req *bankv1.ListTransactionsRequest, stream grpc.ServerStreamingServer[bankv1.Transaction]) error {
+    ctx := stream.Context()
+    if err := s.requireAccount(ctx, req.GetAccountId()); err != nil {
+        return err
+    }
+    _, err := s.sendAfter(ctx, stream, req.GetAccountId(), 0, nil)
+    return err
+}
+
+func (s *transactionServer) WatchTransactions(req *bankv1.WatchTransactionsRequest, stream grpc.ServerStreamingServer[bankv1.Transaction]) error {
+    ctx := stream.Context()
+    if err := s.requireAccount(ctx, req.GetAccountId()); err != nil {
+        return err
+    }
+
+    // The client already has everything up to after_id; of that, only what
+    // could still show up as a late commit needs remembering.
+    lastID := req.GetAfterId()
+    sent := eventstream.NewSentIDs()
+    if err := sent.MarkRecent(s.db.WithContext(ctx), uint(req.GetAccountId()), lastID, time.Now()); err != nil {
+        return internalError(ctx, "Could not fetch transactions", err)
+    }
+
+    ticker := time.NewTicker(s.pollInterval)
+    defer ticker.Stop()
+    for {
+        var err error
+        if lastID, err = s.sendAfter(ctx, stream, req.GetAccountId(), lastID, sent); err != nil {
+            return err
+        }
+        sent.Prune(time.Now())
+        select {
+        case <-ctx.Done():
+            return nil
+        case <-ticker.C:
+        }
+    }
+}
+
+// requireAccount answers NotFound for unknown accounts, and PermissionDenied
+// unless the caller owns the account or is staff, before a stream starts.
+func (s *transactionServer) requireAccount(ctx context.Context, accountID uint64) error {
+    userID, _ := currentUserID(ctx)
+    if _, err := services.AccountForUser(s.db.WithContext(ctx), userID, uint(accountID)); err != nil {
+        return serviceError(ctx, err)
+    }
+    return nil
+}
+
+// sendAfter streams the account's transactions with an ID above afterID in ID
+// order and returns the highest ID sent. With sent, transactions with a lower
+// ID that committed late, inside the late commit window, are sent too, once
+// each.
+func (s *transactionServer) sendAfter(ctx context.Context, stream interface {
+    Send(*bankv1.Transaction) error
+}, accountID, afterID uint64, sent *eventstream.SentIDs) (uint64, error) {
+    since := time.Now().Add(-eventstream.LateCommitWindow)
+    for {
+        var batch []models.Transaction
+        query := s.db.WithContext(ctx).Where("account_id = ?", accountID)
+        if sent == nil {
+            query = query.Where("id > ?", afterID)
+        } else {
+            query = query.Where("(id > ? OR created_at > ?) AND id NOT IN ?", afterID, since, sent.IDs())
+        }
+        if err := query.Order("id").Limit(feedBatchSize).Find(&batch).Error; err != nil {
+            if ctx.Err() != nil {
+                return afterID, nil
+            }
+            return afterID, internalError(ctx, "Could not fetch transactions", err)
+        }
+        for i := range batch {
+            if err := stream.Send(transactionToProto(&batch[i])); err != nil {
+                return afterID, err
+            }
+            if sent != nil {
+                sent.Add(batch[i].ID, batch[i].CreatedAt)
+            }
+            if uint64(batch[i].ID) > afterID {
+                afterID = uint64(batch[i].ID)
+            }
+        }
+        if len(batch) < feedBatchSize {
+            return afterID, nil
+        }
+    }
+}
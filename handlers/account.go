@@ -2,55 +2,57 @@
 package handlers
 
 import (
+    "errors"
     "net/http"
     "strconv"
 
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
 
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
     "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
 )
 
 // CreateAccountHandler creates a new account for a specific user
-func CreateAccountHandler(db *gorm.DB) gin.HandlerFunc {
+func CreateAccountHandler(db *gorm.DB, numCfg config.AccountNumberConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
-        // We expect a JSON body with { "user_id": <number> }
+        db := db.WithContext(c.Request.Context())
+        // We expect a JSON body with { "user_id": <number> } and an optional "product"
         var input struct {
-            UserID uint `json:"user_id" binding:"required"`
+            UserID  uint   `json:"user_id" binding:"required"`
+            Product  string `json:"product"`  // Defaults to "standard"; drives transaction limits
+            Currency string `json:"currency"` // ISO 4217, defaults to EUR
         }
         if err := c.ShouldBindJSON(&input); err != nil {
             c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
             return
         }
 
-        // Initialize an Account model
-        account := models.Account{
-            UserID:  input.UserID,
-            Balance: 0, // default balance
-        }
-
-        if err := db.Create(&account).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        account, err := services.CreateAccount(db, numCfg, input.UserID, input.Product, input.Currency)
+        if err != nil {
+            serviceError(c, err)
             return
         }
+        audit.SetEntity(c, "account", account.ID)
+        audit.SetChange(c, nil, account)
 
         c.JSON(http.StatusCreated, account)
     }
 }
 
-// GetAccountHandler retrieves an account by ID
-func GetAccountHandler(db *gorm.DB) gin.HandlerFunc {
+// GetAccountHandler retrieves an account by ID, account number or IBAN
+func GetAccountHandler(db *gorm.DB, numCfg config.AccountNumberConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
-        // account ID from the URL param
-        accountIDStr := c.Param("id")
-        accountID, err := strconv.Atoi(accountIDStr)
-        if err != nil {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
-            return
-        }
-
+        db := db.WithContext(c.Request.Context())
+        // account ID, account number or IBAN from the URL param
         var account models.Account
-        if err := db.First(&account, accountID).Error; err != nil {
+        if err := services.FindAccountByRef(db, c.Param("id"), numCfg, &account); err != nil {
+            if errors.Is(err, services.ErrInvalidAccountRef) {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID or number"})
+                return
+            }
             c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
             return
         }
@@ -60,8 +62,9 @@ func GetAccountHandler(db *gorm.DB) gin.HandlerFunc {
 }
 
 // DepositHandler deposits a given amount into an account
-func DepositHandler(db *gorm.DB) gin.HandlerFunc {
+func DepositHandler(db *gorm.DB, emailCfg config.EmailConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
         var input struct {
             Amount float64 `json:"amount" binding:"required"`
         }
@@ -77,46 +80,26 @@ func DepositHandler(db *gorm.DB) gin.HandlerFunc {
             return
         }
 
-        var account models.Account
-        if err := db.First(&account, accountID).Error; err != nil {
-            c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
-            return
-        }
-
-		if input.Amount <= 0 {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be positive"})
-            return
-        }
-
-        // Perform deposit
-        account.Balance += input.Amount
-        if err := db.Save(&account).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-            return
-        }
-
-		// Log the transaction
-        txRecord := models.Transaction{
-            AccountID:       account.ID,
-            TransactionType: "deposit",
-            Amount:          input.Amount,
-            Description:     "Deposit operation",
-        }
-        if err := db.Create(&txRecord).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log transaction"})
+        result, err := services.Deposit(db, emailCfg, uint(accountID), input.Amount)
+        if err != nil {
+            serviceError(c, err)
             return
         }
+        audit.SetEntity(c, "account", result.Account.ID)
+        audit.SetChange(c, result.Before, result.Account)
 
         c.JSON(http.StatusOK, gin.H{
-            "account":     account,
-            "transaction": txRecord,
+            "account":     result.Account,
+            "transaction": result.Transaction,
         })
     }
 }
 
-// WithdrawHandler withdraws a given amount from an account and logs a transaction
-func WithdrawHandler(db *gorm.DB) gin.HandlerFunc {
+// WithdrawHandler withdraws a given amount from an account and logs a transaction.
+// The balance and outflow limits are checked in the same DB transaction as the debit (see services.Withdraw).
+func WithdrawHandler(db *gorm.DB, emailCfg config.EmailConfig, limitsCfg config.LimitsConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
         var input struct {
             Amount float64 `json:"amount" binding:"required"`
         }
@@ -132,153 +115,100 @@ func WithdrawHandler(db *gorm.DB) gin.HandlerFunc {
             return
         }
 
-        var account models.Account
-        if err := db.First(&account, accountID).Error; err != nil {
-            c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
-            return
-        }
-
-        if input.Amount <= 0 {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be positive"})
-            return
-        }
-
-        // Check balance
-        if account.Balance < input.Amount {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient balance"})
-            return
-        }
-
-        // Perform withdrawal
-        account.Balance -= input.Amount
-        if err := db.Save(&account).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-            return
-        }
-
-        // Log the transaction
-        txRecord := models.Transaction{
-            AccountID:       account.ID,
-            TransactionType: "withdrawal",
-            Amount:          input.Amount,
-            Description:     "Withdrawal operation",
-        }
-        if err := db.Create(&txRecord).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log transaction"})
+        result, err := services.Withdraw(db, emailCfg, limitsCfg, uint(accountID), input.Amount)
+        if err != nil {
+            serviceError(c, err)
             return
         }
+        audit.SetEntity(c, "account", result.Account.ID)
+        audit.SetChange(c, result.Before, result.Account)
 
-        c.JSON(http.StatusOK, gin.H{
-            "account":     account,
-            "transaction": txRecord,
-        })
+        c.JSON(http.StatusOK, result) // Includes overdraft_fee when the withdrawal went below zero
     }
 }
 
-// TransferHandler transfers an amount from one account to another in a single transaction
-func TransferHandler(db *gorm.DB) gin.HandlerFunc {
+// TransferHandler transfers an amount from one account to another in a single transaction.
+// Accounts are given by ID ("from_account_id") or by account number/IBAN ("from_account").
+// Transfers above the MFA step-up threshold also need a current TOTP code in "mfa_code".
+func TransferHandler(db *gorm.DB, mfaCfg config.MFAConfig, emailCfg config.EmailConfig, numCfg config.AccountNumberConfig, limitsCfg config.LimitsConfig, approvalCfg config.ApprovalConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
         var input struct {
-            FromAccountID uint    `json:"from_account_id" binding:"required"`
-            ToAccountID   uint    `json:"to_account_id" binding:"required"`
+            FromAccountID uint    `json:"from_account_id"`
+            FromAccount   string  `json:"from_account"` // Account number, IBAN or ID
+            ToAccountID   uint    `json:"to_account_id"`
+            ToAccount     string  `json:"to_account"`
             Amount        float64 `json:"amount" binding:"required"`
+            MFACode       string  `json:"mfa_code"`
         }
         if err := c.ShouldBindJSON(&input); err != nil {
             c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
             return
         }
 
-        if input.FromAccountID == input.ToAccountID {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot transfer to the same account"})
-            return
-        }
-
-        if input.Amount <= 0 {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be positive"})
+        var err error
+        if input.FromAccountID, err = services.ResolveAccountID(db, input.FromAccountID, input.FromAccount, numCfg); err != nil {
+            accountRefError(c, "Source", err)
             return
         }
-
-        // Use a DB transaction to ensure both steps succeed or fail together
-        tx := db.Begin()
-
-        var fromAccount, toAccount models.Account
-        // Lock rows "FOR UPDATE" if you want concurrency safety
-        if err := tx.First(&fromAccount, input.FromAccountID).Error; err != nil {
-            tx.Rollback()
-            c.JSON(http.StatusNotFound, gin.H{"error": "Source account not found"})
+        if input.ToAccountID, err = services.ResolveAccountID(db, input.ToAccountID, input.ToAccount, numCfg); err != nil {
+            accountRefError(c, "Destination", err)
             return
         }
 
-        if err := tx.First(&toAccount, input.ToAccountID).Error; err != nil {
-            tx.Rollback()
-            c.JSON(http.StatusNotFound, gin.H{"error": "Destination account not found"})
+        if input.FromAccountID == input.ToAccountID {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot transfer to the same account"})
             return
         }
 
-        // Check balance
-        if fromAccount.Balance < input.Amount {
-            tx.Rollback()
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Insufficient balance"})
+        if input.Amount <= 0 {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be positive"})
             return
         }
 
-        // Perform transfer
-        fromAccount.Balance -= input.Amount
-        toAccount.Balance += input.Amount
-
-        if err := tx.Save(&fromAccount).Error; err != nil {
-            tx.Rollback()
-            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-            return
-        }
-        if err := tx.Save(&toAccount).Error; err != nil {
-            tx.Rollback()
-            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        // Verified email and step-up authentication, checked against the source account's owner
+        source, err := services.AuthorizeTransfer(db, emailCfg, mfaCfg, input.FromAccountID, input.Amount, input.MFACode)
+        if err != nil {
+            stepUpError(c, err)
             return
         }
 
-        // Log transactions
-        fromTx := models.Transaction{
-            AccountID:       fromAccount.ID,
-            TransactionType: "transfer-out",
-            Amount:          input.Amount,
-            Description:     "Transfer to account " + strconv.Itoa(int(toAccount.ID)),
-        }
-        if err := tx.Create(&fromTx).Error; err != nil {
-            tx.Rollback()
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log transfer-out transaction"})
-            return
+        req := services.TransferRequest{
+            FromAccountID: input.FromAccountID,
+            ToAccountID:   input.ToAccountID,
+            Amount:        input.Amount,
         }
-        toTx := models.Transaction{
-            AccountID:       toAccount.ID,
-            TransactionType: "transfer-in",
-            Amount:          input.Amount,
-            Description:     "Transfer from account " + strconv.Itoa(int(fromAccount.ID)),
-        }
-        if err := tx.Create(&toTx).Error; err != nil {
-            tx.Rollback()
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log transfer-in transaction"})
+        // Large transfers wait for a staff member's approval under /pending-actions
+        if services.TransferNeedsApproval(approvalCfg, input.Amount) {
+            action, err := services.ProposeTransfer(db, approvalCfg, req, source.UserID)
+            if err != nil {
+                serviceError(c, err)
+                return
+            }
+            audit.SetEntity(c, "pending_action", action.ID)
+            audit.SetChange(c, nil, action)
+            c.JSON(http.StatusAccepted, action)
             return
         }
 
-        // Commit transaction
-        if err := tx.Commit().Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        // The service checks status, balance and limits in the same DB transaction as the debit
+        result, err := services.Transfer(db, limitsCfg, req)
+        if err != nil {
+            serviceError(c, err)
             return
         }
+        audit.SetEntity(c, "account", source.ID)
+        audit.SetChange(c, *source, result.FromAccount)
+        result.RecordMetrics()
 
-        c.JSON(http.StatusOK, gin.H{
-            "from_account": fromAccount,
-            "to_account":   toAccount,
-            "out_tx":       fromTx,
-            "in_tx":        toTx,
-        })
+        c.JSON(http.StatusOK, result) // Includes overdraft_fee when the transfer went below zero
     }
 }
 
 // GetTransactionsHandler returns a list of transactions for an account
 func GetTransactionsHandler(db *gorm.DB) gin.HandlerFunc {
     return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
         accountIDStr := c.Param("id")
         accountID, err := strconv.Atoi(accountIDStr)
         if err != nil {
@@ -288,7 +218,7 @@ func GetTransactionsHandler(db *gorm.DB) gin.HandlerFunc {
 
         var transactions []models.Transaction
         if err := db.Where("account_id = ?", accountID).Order("created_at DESC").Find(&transactions).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not fetch transactions"})
+            internalError(c, "Could not fetch transactions", err)
             return
         }
 
@@ -296,6 +226,11 @@ func GetTransactionsHandler(db *gorm.DB) gin.HandlerFunc {
     }
 }
 
-
-
-
+// accountRefError answers a failed account lookup from services.ResolveAccountID.
+func accountRefError(c *gin.Context, which string, err error) {
+    if errors.Is(err, services.ErrInvalidAccountRef) {
+        c.JSON(http.StatusBadRequest, gin.H{"error": which + " account is missing or invalid"})
+        return
+    }
+    c.JSON(http.StatusNotFound, gin.H{"error": which + " account not found"})
+}
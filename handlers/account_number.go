@@ -0,0 +1,65 @@
+// handlers/account_number.go
+package handlers
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+    "github.com/bhushangupta162/bank_management/utils"
+)
+
+// BackfillAccountNumbers assigns identifiers to accounts created before
+// account numbers existed (or before IBANs were enabled).
+func BackfillAccountNumbers(db *gorm.DB, cfg config.AccountNumberConfig) error {
+    var accounts []models.Account
+    query := db.Unscoped().Where("account_number IS NULL")
+    if cfg.IBANEnabled {
+        query = query.Or("iban IS NULL")
+    }
+    if err := query.Find(&accounts).Error; err != nil {
+        return err
+    }
+
+    for i := range accounts {
+        account := &accounts[i]
+        if account.AccountNumber == nil {
+            if err := services.AssignAccountIdentifiers(db, account, cfg); err != nil {
+                return err
+            }
+        } else if cfg.IBANEnabled {
+            iban, err := utils.BuildIBAN(cfg.IBANCountry, cfg.IBANBankCode, *account.AccountNumber)
+            if err != nil {
+                return err
+            }
+            account.IBAN = &iban
+        }
+        if err := db.Unscoped().Model(account).Updates(map[string]interface{}{
+            "account_number": account.AccountNumber,
+            "iban":           account.IBAN,
+        }).Error; err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// ValidateIBANHandler checks an IBAN's structure and mod-97 checksum.
+func ValidateIBANHandler() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        iban := c.Query("iban")
+        if iban == "" {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "iban query parameter is required"})
+            return
+        }
+        normalized := utils.NormalizeIBAN(iban)
+        c.JSON(http.StatusOK, gin.H{
+            "iban":  normalized,
+            "valid": utils.ValidateIBAN(normalized),
+        })
+    }
+}
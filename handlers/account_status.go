@@ -0,0 +1,384 @@
+// handlers/account_status.go
+package handlers
+
+import (
+    "errors"
+    "fmt"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+var (
+    errInvalidTransition = errors.New("status transition not allowed")
+    errUnknownReasonCode = errors.New("unknown reason code")
+)
+
+// canAccessAccount allows the account owner and staff. It writes the error
+// response and returns false otherwise.
+func canAccessAccount(c *gin.Context, db *gorm.DB, account *models.Account) bool {
+    user, ok := loadCurrentUser(c, db)
+    if !ok {
+        return false
+    }
+    if user.ID != account.UserID && !user.IsStaff() {
+        c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to access this account"})
+        return false
+    }
+    return true
+}
+
+// requireDebitable rejects debits from frozen or closed accounts.
+func requireDebitable(c *gin.Context, account *models.Account) bool {
+    if !account.CanDebit() {
+        c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("Account %d is %s and cannot be debited", account.ID, account.Status)})
+        return false
+    }
+    return true
+}
+
+// requireCreditable rejects credits to closed accounts.
+func requireCreditable(c *gin.Context, account *models.Account) bool {
+    if !account.CanCredit() {
+        c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("Account %d is %s and cannot receive funds", account.ID, account.Status)})
+        return false
+    }
+    return true
+}
+
+// changeAccountStatus validates and applies a status change and records it in
+// the account's history. It must run inside the caller's DB transaction.
+func changeAccountStatus(tx *gorm.DB, account *models.Account, status, reasonCode, note string, actorID uint) (*models.AccountStatusChange, error) {
+    if _, ok := models.AccountStatusReasonCodes[reasonCode]; !ok {
+        return nil, errUnknownReasonCode
+    }
+    if !account.CanTransitionTo(status) {
+        return nil, errInvalidTransition
+    }
+
+    change := models.AccountStatusChange{
+        AccountID:  account.ID,
+        FromStatus: account.Status,
+        ToStatus:   status,
+        ReasonCode: reasonCode,
+        Note:       note,
+        ActorID:    actorID,
+    }
+
+    updates := map[string]interface{}{"status": status}
+    if status == models.AccountStatusClosed {
+        now := time.Now()
+        account.ClosedAt = &now
+        updates["closed_at"] = now
+    }
+    if err := tx.Model(account).Updates(updates).Error; err != nil {
+        return nil, err
+    }
+    account.Status = status
+
+    if err := tx.Create(&change).Error; err != nil {
+        return nil, err
+    }
+
+    // Published through the outbox in the same DB transaction
+    eventType := accountStatusEvents[status]
+    if err := models.RecordEvent(tx, eventType, "account", account.ID, map[string]interface{}{
+        "account": account,
+        "change":  change,
+    }); err != nil {
+        return nil, err
+    }
+    return &change, nil
+}
+
+// accountStatusEvents maps the new status to the webhook event type.
+var accountStatusEvents = map[string]string{
+    models.AccountStatusFrozen:  models.EventAccountFrozen,
+    models.AccountStatusDormant: models.EventAccountDormant,
+    models.AccountStatusClosed:  models.EventAccountClosed,
+    models.AccountStatusActive:  models.EventAccountReactivated,
+}
+
+// statusChangeError maps changeAccountStatus errors to responses.
+func statusChangeError(c *gin.Context, account *models.Account, status string, err error) {
+    switch {
+    case errors.Is(err, errUnknownReasonCode):
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown reason code"})
+    case errors.Is(err, errInvalidTransition):
+        c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Cannot change account status from %s to %s", account.Status, status)})
+    default:
+        internalError(c, "Could not update account status", err)
+    }
+}
+
+// UpdateAccountStatusHandler - staff freeze, unfreeze or mark an account dormant
+func UpdateAccountStatusHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        accountID, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+            return
+        }
+
+        var input struct {
+            Status     string `json:"status" binding:"required"`      // e.g. "frozen", "active", "dormant"
+            ReasonCode string `json:"reason_code" binding:"required"` // see models.AccountStatusReasonCodes
+            Note       string `json:"note"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+        if !models.IsValidAccountStatus(input.Status) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+            return
+        }
+        // Closing has its own endpoint because the balance has to be settled first.
+        if input.Status == models.AccountStatusClosed {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Use POST /accounts/:id/close to close an account"})
+            return
+        }
+
+        actorID, _ := currentUserID(c)
+
+        var account, before models.Account
+        var change *models.AccountStatusChange
+        err = db.Transaction(func(tx *gorm.DB) error {
+            if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, accountID).Error; err != nil {
+                return err
+            }
+            before = account
+            var err error
+            change, err = changeAccountStatus(tx, &account, input.Status, input.ReasonCode, input.Note, actorID)
+            return err
+        })
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+            return
+        }
+        if err != nil {
+            statusChangeError(c, &account, input.Status, err)
+            return
+        }
+        audit.SetEntity(c, "account", account.ID)
+        audit.SetChange(c, before, account)
+
+        c.JSON(http.StatusOK, gin.H{
+            "account": account,
+            "change":  change,
+        })
+    }
+}
+
+// CloseAccountHandler closes an account. The balance must be zero, or the
+// remaining funds are paid out to another account in the same DB transaction.
+// Owners can only close with the customer_request reason and only accounts
+// that can be debited; a payout is then checked like a transfer (verified
+// email, step-up MFA, outflow limits). Staff can close frozen accounts too.
+func CloseAccountHandler(db *gorm.DB, mfaCfg config.MFAConfig, emailCfg config.EmailConfig, limitsCfg config.LimitsConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        accountID, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+            return
+        }
+
+        var input struct {
+            ReasonCode      string `json:"reason_code" binding:"required"`
+            Note            string `json:"note"`
+            PayoutAccountID uint   `json:"payout_account_id"` // Required when the balance is not zero
+            MFACode         string `json:"mfa_code"`          // Owners, when the payout is above the step-up threshold
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        var account models.Account
+        if err := db.First(&account, accountID).Error; err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+            return
+        }
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        if user.ID != account.UserID && !user.IsStaff() {
+            c.JSON(http.StatusForbidden, gin.H{"error": "Not allowed to access this account"})
+            return
+        }
+        customer := !user.IsStaff()
+        if customer {
+            if !models.CustomerCloseReasonCodes[input.ReasonCode] {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Customers can only close an account with reason_code customer_request"})
+                return
+            }
+            // A frozen account must not be emptied by its owner
+            if !requireDebitable(c, &account) {
+                return
+            }
+            if account.Balance > 0 {
+                if !requireVerifiedEmail(c, db, emailCfg, user.ID) {
+                    return
+                }
+                if !requireStepUpMFA(c, db, mfaCfg, user.ID, account.Balance, input.MFACode) {
+                    return
+                }
+            }
+        }
+
+        var before models.Account
+        var change *models.AccountStatusChange
+        var payoutTx *models.Transaction
+        err = db.Transaction(func(tx *gorm.DB) error {
+            if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, accountID).Error; err != nil {
+                return &services.Error{Kind: services.ErrNotFound, Message: "Account not found"}
+            }
+            before = account
+            if !account.CanTransitionTo(models.AccountStatusClosed) {
+                return &services.Error{Kind: services.ErrConflict, Message: "Account is already closed"}
+            }
+            if customer && !account.CanDebit() {
+                return &services.Error{Kind: services.ErrForbidden, Message: fmt.Sprintf("Account %d is %s and cannot be debited", account.ID, account.Status)}
+            }
+            if account.Balance < 0 {
+                return &services.Error{Kind: services.ErrInvalid, Message: "Account has a negative balance and cannot be closed"}
+            }
+            if account.HeldAmount > 0 {
+                return &services.Error{Kind: services.ErrConflict, Message: "Account has active holds; capture or release them first"}
+            }
+
+            if account.Balance > 0 {
+                if customer {
+                    if err := services.CheckOutflowLimits(tx, &account, account.Balance, true, limitsCfg); err != nil {
+                        return err
+                    }
+                }
+                var err error
+                if payoutTx, err = payOutClosingBalance(tx, &account, input.PayoutAccountID); err != nil {
+                    return err
+                }
+            }
+
+            var err error
+            change, err = changeAccountStatus(tx, &account, models.AccountStatusClosed, input.ReasonCode, input.Note, user.ID)
+            return err
+        })
+        if errors.Is(err, errUnknownReasonCode) || errors.Is(err, errInvalidTransition) {
+            statusChangeError(c, &account, models.AccountStatusClosed, err)
+            return
+        }
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "account", account.ID)
+        audit.SetChange(c, before, account)
+
+        c.JSON(http.StatusOK, gin.H{
+            "account":   account,
+            "change":    change,
+            "payout_tx": payoutTx,
+        })
+    }
+}
+
+// payOutClosingBalance moves the whole balance of an account being closed to
+// the payout account and logs both legs. It must run inside the caller's DB
+// transaction with the account locked.
+func payOutClosingBalance(tx *gorm.DB, account *models.Account, payoutAccountID uint) (*models.Transaction, error) {
+    if payoutAccountID == 0 {
+        return nil, &services.Error{Kind: services.ErrInvalid, Message: "Balance is not zero; provide payout_account_id"}
+    }
+    if payoutAccountID == account.ID {
+        return nil, &services.Error{Kind: services.ErrInvalid, Message: "Payout account must be a different account"}
+    }
+
+    var payout models.Account
+    if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payout, payoutAccountID).Error; err != nil {
+        return nil, &services.Error{Kind: services.ErrNotFound, Message: "Payout account not found"}
+    }
+    if !payout.CanCredit() {
+        return nil, &services.Error{Kind: services.ErrForbidden, Message: fmt.Sprintf("Account %d is %s and cannot receive funds", payout.ID, payout.Status)}
+    }
+    if payout.Currency != account.Currency {
+        return nil, &services.Error{Kind: services.ErrInvalid, Message: "Payout account must hold the same currency"}
+    }
+
+    amount := account.Balance
+    account.Balance = 0
+    payout.Balance += amount
+    if err := tx.Save(account).Error; err != nil {
+        return nil, err
+    }
+    if err := tx.Save(&payout).Error; err != nil {
+        return nil, err
+    }
+
+    outTx := models.Transaction{
+        AccountID:       account.ID,
+        TransactionType: models.TransactionTransferOut,
+        Amount:          amount,
+        Description:     "Closing payout to account " + strconv.Itoa(int(payout.ID)),
+        BalanceAfter:    account.Balance,
+    }
+    inTx := models.Transaction{
+        AccountID:       payout.ID,
+        TransactionType: models.TransactionTransferIn,
+        Amount:          amount,
+        Description:     "Closing payout from account " + strconv.Itoa(int(account.ID)),
+        BalanceAfter:    payout.Balance,
+    }
+    if err := tx.Create(&outTx).Error; err != nil {
+        return nil, err
+    }
+    inTx.CounterpartID = &outTx.ID
+    if err := tx.Create(&inTx).Error; err != nil {
+        return nil, err
+    }
+    outTx.CounterpartID = &inTx.ID
+    if err := tx.Model(&outTx).Update("counterpart_id", inTx.ID).Error; err != nil {
+        return nil, err
+    }
+    return &outTx, nil
+}
+
+// GetAccountStatusHistoryHandler lists every status change of an account, oldest first.
+func GetAccountStatusHistoryHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        accountID, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+            return
+        }
+
+        var account models.Account
+        if err := db.First(&account, accountID).Error; err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+            return
+        }
+        if !canAccessAccount(c, db, &account) {
+            return
+        }
+
+        var history []models.AccountStatusChange
+        if err := db.Where("account_id = ?", accountID).Order("created_at ASC, id ASC").Find(&history).Error; err != nil {
+            internalError(c, "Could not fetch status history", err)
+            return
+        }
+
+        c.JSON(http.StatusOK, history)
+    }
+}
@@ -0,0 +1,50 @@
+// handlers/adjustment.go
+package handlers
+
+import (
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// AdjustBalanceHandler - staff propose a manual balance correction (positive
+// credits, negative debits). It is booked once a second staff member approves
+// the pending action.
+func AdjustBalanceHandler(db *gorm.DB, approvalCfg config.ApprovalConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        accountID, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+            return
+        }
+        var input struct {
+            Amount float64 `json:"amount" binding:"required"`
+            Reason string  `json:"reason" binding:"required"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        makerID, _ := currentUserID(c)
+        action, err := services.ProposeAdjustment(db, approvalCfg, services.BalanceAdjustment{
+            AccountID: uint(accountID),
+            Amount:    input.Amount,
+            Reason:    input.Reason,
+        }, makerID)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "pending_action", action.ID)
+        audit.SetChange(c, nil, action)
+        c.JSON(http.StatusAccepted, action)
+    }
+}
@@ -0,0 +1,88 @@
+// handlers/audit.go
+package handlers
+
+import (
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// ListAuditEntriesHandler searches the audit log, newest first. Filters:
+// entity_type, entity_id, actor_id, action, request_id, from and to (RFC 3339
+// or YYYY-MM-DD), plus limit (max 500) and offset.
+func ListAuditEntriesHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        query := db.Model(&models.AuditEntry{})
+        if v := c.Query("entity_type"); v != "" {
+            query = query.Where("entity_type = ?", v)
+        }
+        if v := c.Query("entity_id"); v != "" {
+            query = query.Where("entity_id = ?", v)
+        }
+        if v := c.Query("actor_id"); v != "" {
+            actorID, err := strconv.ParseUint(v, 10, 64)
+            if err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid actor_id"})
+                return
+            }
+            query = query.Where("actor_id = ?", actorID)
+        }
+        if v := c.Query("action"); v != "" {
+            query = query.Where("action = ?", v)
+        }
+        if v := c.Query("request_id"); v != "" {
+            query = query.Where("request_id = ?", v)
+        }
+        if v := c.Query("from"); v != "" {
+            from, err := parseScheduleTime(v)
+            if err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date"})
+                return
+            }
+            query = query.Where("created_at >= ?", from)
+        }
+        if v := c.Query("to"); v != "" {
+            to, err := parseScheduleTime(v)
+            if err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date"})
+                return
+            }
+            query = query.Where("created_at < ?", to)
+        }
+
+        limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+        if limit <= 0 || limit > 500 {
+            limit = 100
+        }
+        offset, _ := strconv.Atoi(c.Query("offset"))
+        if offset < 0 {
+            offset = 0
+        }
+
+        var entries []models.AuditEntry
+        if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+            internalError(c, "Could not fetch audit log", err)
+            return
+        }
+        c.JSON(http.StatusOK, entries)
+    }
+}
+
+// VerifyAuditLogHandler recomputes the hash chain and reports the first broken entry.
+func VerifyAuditLogHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        result, err := audit.Verify(db)
+        if err != nil {
+            internalError(c, "Could not verify audit log", err)
+            return
+        }
+        c.JSON(http.StatusOK, result)
+    }
+}
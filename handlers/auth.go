@@ -8,13 +8,17 @@ import (
     "golang.org/x/crypto/bcrypt"
     "gorm.io/gorm"
 
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/mailer"
     "github.com/bhushangupta162/bank_management/models"
-    "github.com/bhushangupta162/bank_management/utils"
+    "github.com/bhushangupta162/bank_management/services"
 )
 
-// SignUpHandler handles user registration.
-func SignUpHandler(db *gorm.DB) gin.HandlerFunc {
+// SignUpHandler handles user registration and sends the email verification link.
+func SignUpHandler(db *gorm.DB, m mailer.Mailer, emailCfg config.EmailConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
         var user models.User
         if err := c.ShouldBindJSON(&user); err != nil {
             c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -24,23 +28,32 @@ func SignUpHandler(db *gorm.DB) gin.HandlerFunc {
         // Hash the password.
         hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
         if err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Error hashing password"})
+            internalError(c, "Error hashing password", err)
             return
         }
         user.Password = string(hashedPassword)
+        user.Role = models.RoleCustomer // Roles are never self-assigned
 
         // Save the user to the database.
         if err := db.Create(&user).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+            internalError(c, "Could not create user", err)
             return
         }
-        c.JSON(http.StatusCreated, gin.H{"message": "User created successfully"})
+
+        // The account exists even if the email fails; the user can ask for a new link.
+        if err := sendVerificationEmail(db, m, emailCfg, &user); err != nil {
+            logging.From(c).Error("verification email failed", "user_id", user.ID, "error", err)
+        }
+        c.JSON(http.StatusCreated, gin.H{"message": "User created successfully. Check your email to verify your address."})
     }
 }
 
 // LoginHandler handles user login.
-func LoginHandler(db *gorm.DB) gin.HandlerFunc {
+// Users with two-factor authentication enabled receive a short-lived MFA
+// challenge token instead, to be exchanged at /login/mfa.
+func LoginHandler(db *gorm.DB, mfaCfg config.MFAConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
         var input struct {
             Email    string `json:"email" binding:"required"`
             Password string `json:"password" binding:"required"`
@@ -50,23 +63,15 @@ func LoginHandler(db *gorm.DB) gin.HandlerFunc {
             return
         }
 
-        var user models.User
-        if err := db.Where("email = ?", input.Email).First(&user).Error; err != nil {
-            c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
-            return
-        }
-
-        if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); err != nil {
-            c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+        result, err := services.Login(db, mfaCfg, input.Email, input.Password)
+        if err != nil {
+            serviceError(c, err)
             return
         }
-
-        // Generate a JWT token.
-        tokenString, err := utils.GenerateToken(user.ID)
-        if err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not generate token"})
+        if result.MFAToken != "" {
+            c.JSON(http.StatusOK, gin.H{"mfa_required": true, "mfa_token": result.MFAToken})
             return
         }
-        c.JSON(http.StatusOK, gin.H{"token": tokenString})
+        c.JSON(http.StatusOK, gin.H{"token": result.Token})
     }
 }
@@ -0,0 +1,264 @@
+// handlers/email.go
+package handlers
+
+import (
+    _ "embed"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/url"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "golang.org/x/crypto/bcrypt"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/apiversion"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/mailer"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+    "github.com/bhushangupta162/bank_management/utils"
+)
+
+var errInvalidUserToken = errors.New("invalid or expired token")
+
+// resetPasswordHTML is the page the password reset email links to. It posts
+// the token from its URL and the new password to POST /reset-password.
+//
+//go:embed reset_password.html
+var resetPasswordHTML []byte
+
+// issueUserToken creates a new single-use token for the user and returns the
+// plaintext value. Earlier unused tokens with the same purpose stop working.
+func issueUserToken(db *gorm.DB, userID uint, purpose string, ttl time.Duration) (string, error) {
+    token, err := utils.GenerateRandomToken(32)
+    if err != nil {
+        return "", err
+    }
+    err = db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Unscoped().
+            Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+            Delete(&models.UserToken{}).Error; err != nil {
+            return err
+        }
+        return tx.Create(&models.UserToken{
+            UserID:    userID,
+            Purpose:   purpose,
+            TokenHash: utils.HashToken(token),
+            ExpiresAt: time.Now().Add(ttl),
+        }).Error
+    })
+    if err != nil {
+        return "", err
+    }
+    return token, nil
+}
+
+// consumeUserToken marks a valid token as used and returns it. The conditional
+// update makes sure a token can only be redeemed once, even under concurrency.
+func consumeUserToken(tx *gorm.DB, token, purpose string) (*models.UserToken, error) {
+    var record models.UserToken
+    if err := tx.Where("token_hash = ? AND purpose = ?", utils.HashToken(token), purpose).First(&record).Error; err != nil {
+        return nil, errInvalidUserToken
+    }
+    if record.UsedAt != nil || time.Now().After(record.ExpiresAt) {
+        return nil, errInvalidUserToken
+    }
+
+    now := time.Now()
+    res := tx.Model(&models.UserToken{}).
+        Where("id = ? AND used_at IS NULL", record.ID).
+        Update("used_at", now)
+    if res.Error != nil {
+        return nil, res.Error
+    }
+    if res.RowsAffected != 1 {
+        return nil, errInvalidUserToken
+    }
+    record.UsedAt = &now
+    return &record, nil
+}
+
+// sendVerificationEmail issues a verification token and mails the link to the user.
+func sendVerificationEmail(db *gorm.DB, m mailer.Mailer, cfg config.EmailConfig, user *models.User) error {
+    token, err := issueUserToken(db, user.ID, models.TokenPurposeEmailVerification, cfg.VerificationTTL)
+    if err != nil {
+        return err
+    }
+    link := cfg.BaseURL + apiversion.V1 + "/verify-email?token=" + url.QueryEscape(token)
+    return m.Send(mailer.Message{
+        To:      user.Email,
+        Subject: "Verify your email address",
+        Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+            user.Username, link, cfg.VerificationTTL),
+    })
+}
+
+// VerifyEmailHandler confirms a user's email address. The token can be sent as
+// a query parameter (link in the email) or in a JSON body.
+func VerifyEmailHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        token := c.Query("token")
+        if token == "" {
+            var input struct {
+                Token string `json:"token" binding:"required"`
+            }
+            if err := c.ShouldBindJSON(&input); err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+                return
+            }
+            token = input.Token
+        }
+
+        err := db.Transaction(func(tx *gorm.DB) error {
+            record, err := consumeUserToken(tx, token, models.TokenPurposeEmailVerification)
+            if err != nil {
+                return err
+            }
+            return tx.Model(&models.User{}).
+                Where("id = ? AND email_verified_at IS NULL", record.UserID).
+                Update("email_verified_at", time.Now()).Error
+        })
+        if errors.Is(err, errInvalidUserToken) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+            return
+        }
+        if err != nil {
+            internalError(c, "Could not verify email", err)
+            return
+        }
+
+        c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+    }
+}
+
+// ResendVerificationHandler sends a new verification email to the authenticated user.
+func ResendVerificationHandler(db *gorm.DB, m mailer.Mailer, cfg config.EmailConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        if user.EmailVerifiedAt != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Email is already verified"})
+            return
+        }
+        if err := sendVerificationEmail(db, m, cfg, user); err != nil {
+            internalError(c, "Could not send verification email", err)
+            return
+        }
+        c.JSON(http.StatusOK, gin.H{"message": "Verification email sent"})
+    }
+}
+
+// ForgotPasswordHandler emails a password reset link. It always answers with the
+// same message so it cannot be used to find out which emails are registered.
+func ForgotPasswordHandler(db *gorm.DB, m mailer.Mailer, cfg config.EmailConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input struct {
+            Email string `json:"email" binding:"required"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        response := gin.H{"message": "If the email is registered, a reset link has been sent"}
+
+        var user models.User
+        if err := db.Where("email = ?", input.Email).First(&user).Error; err != nil {
+            c.JSON(http.StatusOK, response)
+            return
+        }
+
+        token, err := issueUserToken(db, user.ID, models.TokenPurposePasswordReset, cfg.ResetTTL)
+        if err != nil {
+            internalError(c, "Could not create reset token", err)
+            return
+        }
+        link := cfg.BaseURL + apiversion.V1 + "/reset-password?token=" + url.QueryEscape(token)
+        err = m.Send(mailer.Message{
+            To:      user.Email,
+            Subject: "Reset your password",
+            Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset your password. If it was you, open the link below:\n\n%s\n\nThe link expires in %s. If you did not ask for this, you can ignore this email.\n",
+                user.Username, link, cfg.ResetTTL),
+        })
+        if err != nil {
+            logging.From(c).Error("password reset email failed", "user_id", user.ID, "error", err)
+        }
+
+        c.JSON(http.StatusOK, response)
+    }
+}
+
+// ResetPasswordPageHandler serves the page the reset link opens, where the user
+// picks a new password. The token stays in the URL, so the page is not cached
+// and sends no Referer.
+func ResetPasswordPageHandler() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        c.Header("Cache-Control", "no-store")
+        c.Header("Referrer-Policy", "no-referrer")
+        c.Data(http.StatusOK, "text/html; charset=utf-8", resetPasswordHTML)
+    }
+}
+
+// ResetPasswordHandler sets a new password using a reset token.
+func ResetPasswordHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input struct {
+            Token       string `json:"token" binding:"required"`
+            NewPassword string `json:"new_password" binding:"required"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.NewPassword), bcrypt.DefaultCost)
+        if err != nil {
+            internalError(c, "Error hashing password", err)
+            return
+        }
+
+        err = db.Transaction(func(tx *gorm.DB) error {
+            record, err := consumeUserToken(tx, input.Token, models.TokenPurposePasswordReset)
+            if err != nil {
+                return err
+            }
+            if err := tx.Model(&models.User{}).Where("id = ?", record.UserID).
+                Update("password", string(hashedPassword)).Error; err != nil {
+                return err
+            }
+            // Any other outstanding reset links stop working.
+            return tx.Unscoped().
+                Where("user_id = ? AND purpose = ? AND used_at IS NULL", record.UserID, models.TokenPurposePasswordReset).
+                Delete(&models.UserToken{}).Error
+        })
+        if errors.Is(err, errInvalidUserToken) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+            return
+        }
+        if err != nil {
+            internalError(c, "Could not reset password", err)
+            return
+        }
+
+        c.JSON(http.StatusOK, gin.H{"message": "Password updated"})
+    }
+}
+
+// requireVerifiedEmail blocks money movement for users whose email is not verified.
+// It writes the error response and returns false when the check fails.
+func requireVerifiedEmail(c *gin.Context, db *gorm.DB, cfg config.EmailConfig, userID uint) bool {
+    if err := services.RequireVerifiedEmail(db, cfg, userID); err != nil {
+        serviceError(c, err)
+        return false
+    }
+    return true
+}
@@ -0,0 +1,47 @@
+// handlers/errors.go
+package handlers
+
+import (
+    "errors"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// internalError logs an unexpected failure with the request's logger and
+// answers 500 with a message that does not expose the cause.
+func internalError(c *gin.Context, message string, err error) {
+    logging.From(c).Error(message, "error", err)
+    c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+}
+
+// serviceError writes the HTTP response for an error returned by the services package.
+// Unexpected errors become 500s without leaking details.
+func serviceError(c *gin.Context, err error) {
+    var limitErr *services.LimitError
+    if errors.As(err, &limitErr) {
+        c.JSON(http.StatusForbidden, gin.H{"error": limitErr.Error(), "limit": limitErr.Limit, "scope": limitErr.Scope})
+        return
+    }
+
+    var svcErr *services.Error
+    if !errors.As(err, &svcErr) {
+        internalError(c, "Internal error", err)
+        return
+    }
+    switch {
+    case errors.Is(svcErr, services.ErrUnauthenticated):
+        c.JSON(http.StatusUnauthorized, gin.H{"error": svcErr.Message})
+    case errors.Is(svcErr, services.ErrNotFound):
+        c.JSON(http.StatusNotFound, gin.H{"error": svcErr.Message})
+    case errors.Is(svcErr, services.ErrForbidden):
+        c.JSON(http.StatusForbidden, gin.H{"error": svcErr.Message})
+    case errors.Is(svcErr, services.ErrConflict):
+        c.JSON(http.StatusConflict, gin.H{"error": svcErr.Message})
+    default:
+        c.JSON(http.StatusBadRequest, gin.H{"error": svcErr.Message})
+    }
+}
@@ -0,0 +1,161 @@
+// handlers/hold.go
+package handlers
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// holdStatuses are the values accepted by ?status= on the hold list.
+var holdStatuses = map[string]bool{
+    models.HoldStatusActive:   true,
+    models.HoldStatusCaptured: true,
+    models.HoldStatusReleased: true,
+    models.HoldStatusExpired:  true,
+}
+
+// CreateHoldHandler reserves funds on an account (owner or staff). The hold
+// lowers the available balance until it is captured, released or expires.
+func CreateHoldHandler(db *gorm.DB, emailCfg config.EmailConfig, limitsCfg config.LimitsConfig, holdCfg config.HoldConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        account, ok := loadHoldAccount(c, db)
+        if !ok {
+            return
+        }
+        var input struct {
+            Amount      float64    `json:"amount" binding:"required"`
+            Description string     `json:"description"`
+            Reference   string     `json:"reference"`  // e.g. a card authorization code
+            ExpiresAt   *time.Time `json:"expires_at"` // Defaults to HOLD_TTL from now
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        userID, _ := currentUserID(c)
+        result, err := services.CreateHold(db, emailCfg, limitsCfg, holdCfg, services.HoldRequest{
+            AccountID:   account.ID,
+            Amount:      input.Amount,
+            Description: input.Description,
+            Reference:   input.Reference,
+            ExpiresAt:   input.ExpiresAt,
+        }, userID)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "hold", result.Hold.ID)
+        audit.SetChange(c, nil, result.Hold)
+        c.JSON(http.StatusCreated, result)
+    }
+}
+
+// ListHoldsHandler lists an account's holds, newest first (owner or staff).
+func ListHoldsHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        account, ok := loadHoldAccount(c, db)
+        if !ok {
+            return
+        }
+        status := c.Query("status")
+        if status != "" && !holdStatuses[status] {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+            return
+        }
+        holds, err := services.ListHolds(db, account.ID, status)
+        if err != nil {
+            internalError(c, "Could not fetch holds", err)
+            return
+        }
+        c.JSON(http.StatusOK, holds)
+    }
+}
+
+// CaptureHoldHandler settles a hold into a hold-capture transaction (owner or
+// staff, checked by services.CaptureHold). Without an amount the whole hold is
+// captured.
+func CaptureHoldHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        holdID, ok := holdIDParam(c)
+        if !ok {
+            return
+        }
+        var input struct {
+            Amount float64 `json:"amount"`
+        }
+        if !bindOptionalJSON(c, &input) {
+            return
+        }
+        userID, _ := currentUserID(c)
+        result, err := services.CaptureHold(db, holdID, input.Amount, userID)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "account", result.Account.ID)
+        audit.SetChange(c, result.Before, result.Account)
+        c.JSON(http.StatusOK, result)
+    }
+}
+
+// ReleaseHoldHandler cancels a hold (owner or staff, checked by
+// services.ReleaseHold).
+func ReleaseHoldHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        holdID, ok := holdIDParam(c)
+        if !ok {
+            return
+        }
+        userID, _ := currentUserID(c)
+        result, err := services.ReleaseHold(db, holdID, userID)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "account", result.Account.ID)
+        audit.SetChange(c, result.Before, result.Account)
+        c.JSON(http.StatusOK, result)
+    }
+}
+
+// loadHoldAccount loads the account in :id for the owner or staff.
+func loadHoldAccount(c *gin.Context, db *gorm.DB) (*models.Account, bool) {
+    accountID, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+        return nil, false
+    }
+    var account models.Account
+    if err := db.First(&account, accountID).Error; err != nil {
+        c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+        return nil, false
+    }
+    if !canAccessAccount(c, db, &account) {
+        return nil, false
+    }
+    return &account, true
+}
+
+// holdIDParam parses the hold ID in :id.
+func holdIDParam(c *gin.Context) (uint, bool) {
+    holdID, err := strconv.Atoi(c.Param("id"))
+    if err != nil || holdID <= 0 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hold ID"})
+        return 0, false
+    }
+    return uint(holdID), true
+}
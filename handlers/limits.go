@@ -0,0 +1,122 @@
+// handlers/limits.go
+package handlers
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// GetAccountLimitsHandler shows the limits that apply to an account and how much is left.
+func GetAccountLimitsHandler(db *gorm.DB, defaults config.LimitsConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        accountID, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+            return
+        }
+
+        var account models.Account
+        if err := db.First(&account, accountID).Error; err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+            return
+        }
+        if !canAccessAccount(c, db, &account) {
+            return
+        }
+
+        usages, err := services.AccountLimitUsage(db, &account, defaults, time.Now())
+        if err != nil {
+            internalError(c, "Could not compute limits", err)
+            return
+        }
+
+        c.JSON(http.StatusOK, gin.H{
+            "account_id": account.ID,
+            "product":    account.Product,
+            "limits":     usages,
+        })
+    }
+}
+
+// limitInput is the body for setting limits. Zero means unlimited.
+type limitInput struct {
+    SingleMax           float64 `json:"single_max" binding:"gte=0"`
+    DailyMax            float64 `json:"daily_max" binding:"gte=0"`
+    MonthlyMax          float64 `json:"monthly_max" binding:"gte=0"`
+    HourlyTransferCount int     `json:"hourly_transfer_count" binding:"gte=0"`
+}
+
+// upsertLimit creates or replaces the limit row for a scope/target.
+func upsertLimit(c *gin.Context, db *gorm.DB, limit models.TransactionLimit) {
+    var input limitInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    limit.SingleMax = input.SingleMax
+    limit.DailyMax = input.DailyMax
+    limit.MonthlyMax = input.MonthlyMax
+    limit.HourlyTransferCount = input.HourlyTransferCount
+
+    err := db.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "scope"}, {Name: "product"}, {Name: "user_id"}},
+        DoUpdates: clause.AssignmentColumns([]string{"single_max", "daily_max", "monthly_max", "hourly_transfer_count", "updated_at"}),
+    }).Create(&limit).Error
+    if err != nil {
+        internalError(c, "Could not save limits", err)
+        return
+    }
+    audit.SetEntity(c, "transaction_limit", limit.ID)
+    audit.SetChange(c, nil, limit)
+    c.JSON(http.StatusOK, limit)
+}
+
+// SetProductLimitHandler - staff set the limits for an account product
+func SetProductLimitHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        upsertLimit(c, db, models.TransactionLimit{Scope: models.LimitScopeProduct, Product: c.Param("product")})
+    }
+}
+
+// SetUserLimitHandler - staff set the limits across all of a user's accounts
+func SetUserLimitHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        userID, err := strconv.Atoi(c.Param("id"))
+        if err != nil || userID <= 0 {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+            return
+        }
+        if err := db.First(&models.User{}, userID).Error; err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+            return
+        }
+        upsertLimit(c, db, models.TransactionLimit{Scope: models.LimitScopeUser, UserID: uint(userID)})
+    }
+}
+
+// ListLimitsHandler lists every configured limit row.
+func ListLimitsHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var limits []models.TransactionLimit
+        if err := db.Order("scope, product, user_id").Find(&limits).Error; err != nil {
+            internalError(c, "Could not fetch limits", err)
+            return
+        }
+        c.JSON(http.StatusOK, limits)
+    }
+}
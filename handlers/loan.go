@@ -4,48 +4,56 @@ package handlers
 import (
     "net/http"
     "strconv"
+    "time"
 
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
 
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
     "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
 )
 
-// ApplyLoanHandler - a user requests a new loan
-func ApplyLoanHandler(db *gorm.DB) gin.HandlerFunc {
+// ApplyLoanHandler - a user requests a new loan; underwriting prices it and
+// approves, declines or refers it to staff
+func ApplyLoanHandler(db *gorm.DB, underwritingCfg config.UnderwritingConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
         var input struct {
-            UserID       uint    `json:"user_id" binding:"required"`
-            Principal    float64 `json:"principal" binding:"required"`
-            InterestRate float64 `json:"interest_rate" binding:"required"`
-            TermMonths   int     `json:"term_months" binding:"required"`
+            UserID     uint    `json:"user_id" binding:"required"`
+            ProductID  uint    `json:"product_id" binding:"required"` // See GET /loan-products
+            Principal  float64 `json:"principal" binding:"required"`
+            TermMonths int     `json:"term_months" binding:"required"`
         }
         if err := c.ShouldBindJSON(&input); err != nil {
             c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
             return
         }
 
-        loan := models.Loan{
-            UserID:            input.UserID,
-            Principal:         input.Principal,
-            InterestRate:      input.InterestRate,
-            TermMonths:        input.TermMonths,
-            Status:            "pending",
-            OutstandingBalance: input.Principal,
-        }
-
-        if err := db.Create(&loan).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create loan"})
+        loan, err := services.ApplyLoan(db, underwritingCfg, services.LoanApplication{
+            UserID:     input.UserID,
+            ProductID:  input.ProductID,
+            Principal:  input.Principal,
+            TermMonths: input.TermMonths,
+        })
+        if err != nil {
+            serviceError(c, err)
             return
         }
+        audit.SetEntity(c, "loan", loan.ID)
+        audit.SetChange(c, nil, loan)
 
         c.JSON(http.StatusCreated, loan)
     }
 }
 
-// UpdateLoanStatusHandler - admin approves or rejects a loan
-func UpdateLoanStatusHandler(db *gorm.DB) gin.HandlerFunc {
+// UpdateLoanStatusHandler - a staff member proposes approving, rejecting,
+// cancelling, defaulting or writing off a loan; it takes effect once a second
+// staff member approves the pending action
+func UpdateLoanStatusHandler(db *gorm.DB, approvalCfg config.ApprovalConfig) gin.HandlerFunc {
     return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
         loanIDStr := c.Param("id")
         loanID, err := strconv.Atoi(loanIDStr)
         if err != nil {
@@ -54,105 +62,232 @@ func UpdateLoanStatusHandler(db *gorm.DB) gin.HandlerFunc {
         }
 
         var input struct {
-            Status string `json:"status" binding:"required"` // e.g. "approved", "rejected"
+            Status string `json:"status" binding:"required"` // One of models.ManualLoanStatuses
         }
         if err := c.ShouldBindJSON(&input); err != nil {
             c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
             return
         }
 
-        var loan models.Loan
-        if err := db.First(&loan, loanID).Error; err != nil {
-            c.JSON(http.StatusNotFound, gin.H{"error": "Loan not found"})
+        makerID, _ := currentUserID(c)
+        action, err := services.ProposeLoanDecision(db, approvalCfg, uint(loanID), input.Status, makerID)
+        if err != nil {
+            serviceError(c, err)
             return
         }
+        audit.SetEntity(c, "pending_action", action.ID)
+        audit.SetChange(c, nil, action)
+
+        c.JSON(http.StatusAccepted, action)
+    }
+}
 
-        // Only allow transitions from pending -> [approved or rejected], for example
-        if loan.Status != "pending" {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Loan is not pending"})
+// RepayLoanHandler - user repays part or all of a loan. Money beyond what is
+// due prepays principal and shortens the term or reduces the installment
+func RepayLoanHandler(db *gorm.DB, loanCfg config.LoanConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        loanIDStr := c.Param("id")
+        loanID, err := strconv.Atoi(loanIDStr)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
             return
         }
 
-        if input.Status == "approved" {
-            loan.Status = "active" // or "approved"
-            // Optionally set a start date or schedule interest calculation
-        } else if input.Status == "rejected" {
-            loan.Status = "rejected"
-            // No further changes needed
-        } else {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+        var input struct {
+            Amount     float64 `json:"amount" binding:"required"`
+            Prepayment string  `json:"prepayment"` // "shorten_term" or "reduce_installment"; LOAN_PREPAYMENT when empty
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
             return
         }
 
-        if err := db.Save(&loan).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update loan status"})
+        repayment, err := services.RepayLoan(db, loanCfg, services.RepaymentRequest{
+            LoanID:     uint(loanID),
+            Amount:     input.Amount,
+            Prepayment: input.Prepayment,
+        })
+        if err != nil {
+            serviceError(c, err)
             return
         }
+        audit.SetEntity(c, "loan", repayment.Loan.ID)
+        audit.SetChange(c, repayment.Before, repayment.Loan)
 
-        c.JSON(http.StatusOK, loan)
+        c.JSON(http.StatusOK, repayment)
     }
 }
 
-// RepayLoanHandler - user repays part of a loan
-func RepayLoanHandler(db *gorm.DB) gin.HandlerFunc {
+// DisburseLoanHandler - staff pay an approved loan out into one of the
+// borrower's accounts, which activates it and creates its repayment schedule
+func DisburseLoanHandler(db *gorm.DB) gin.HandlerFunc {
     return func(c *gin.Context) {
-        loanIDStr := c.Param("id")
-        loanID, err := strconv.Atoi(loanIDStr)
+        db := db.WithContext(c.Request.Context())
+        loanID, err := strconv.Atoi(c.Param("id"))
         if err != nil {
             c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
             return
         }
-
         var input struct {
-            Amount float64 `json:"amount" binding:"required"`
+            AccountID uint `json:"account_id" binding:"required"`
         }
         if err := c.ShouldBindJSON(&input); err != nil {
             c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
             return
         }
 
-        var loan models.Loan
-        if err := db.First(&loan, loanID).Error; err != nil {
-            c.JSON(http.StatusNotFound, gin.H{"error": "Loan not found"})
+        d, err := services.DisburseLoan(db, uint(loanID), input.AccountID)
+        if err != nil {
+            serviceError(c, err)
             return
         }
+        audit.SetEntity(c, "loan", d.Loan.ID)
+        audit.SetChange(c, d.Before, d.Loan)
+
+        c.JSON(http.StatusOK, d)
+    }
+}
+
+// loadLoan fetches the loan named in the path for its borrower or staff. It
+// writes the error response and returns false otherwise.
+func loadLoan(c *gin.Context, db *gorm.DB) (*models.Loan, bool) {
+    loanID, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+        return nil, false
+    }
+    user, ok := loadCurrentUser(c, db)
+    if !ok {
+        return nil, false
+    }
+    var loan models.Loan
+    if err := db.First(&loan, loanID).Error; err != nil || (loan.UserID != user.ID && !user.IsStaff()) {
+        c.JSON(http.StatusNotFound, gin.H{"error": "Loan not found"})
+        return nil, false
+    }
+    return &loan, true
+}
 
-        // Check status
-        if loan.Status != "active" {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Loan is not active for repayment"})
+// GetLoanHandler returns a loan to its borrower or to staff.
+func GetLoanHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        loan, ok := loadLoan(c, db)
+        if !ok {
             return
         }
+        c.JSON(http.StatusOK, loan)
+    }
+}
 
-        if input.Amount <= 0 {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Repayment amount must be positive"})
+// GetLoanScheduleHandler returns a loan's repayment schedule to its borrower or
+// to staff. It is empty until the loan is paid out.
+func GetLoanScheduleHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        loan, ok := loadLoan(c, db)
+        if !ok {
             return
         }
-
-        if input.Amount > loan.OutstandingBalance {
-            input.Amount = loan.OutstandingBalance // or reject if you want strict
+        installments, err := services.LoanSchedule(db, loan.ID)
+        if err != nil {
+            internalError(c, "Could not fetch the schedule", err)
+            return
         }
+        c.JSON(http.StatusOK, installments)
+    }
+}
 
-        // Deduct from the outstanding balance
-        loan.OutstandingBalance -= input.Amount
-        if loan.OutstandingBalance <= 0 {
-            loan.OutstandingBalance = 0
-            loan.Status = "closed" // fully repaid
+// GetLoanPayoffHandler quotes what it costs to close a loan, today or on the
+// date given as ?date=YYYY-MM-DD (end of that day) or RFC 3339.
+func GetLoanPayoffHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        loan, ok := loadLoan(c, db)
+        if !ok {
+            return
         }
-
-        if err := db.Save(&loan).Error; err != nil {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update loan"})
+        asOf := time.Now()
+        if v := c.Query("date"); v != "" {
+            var err error
+            if asOf, err = time.Parse("2006-01-02", v); err == nil {
+                asOf = asOf.AddDate(0, 0, 1).Add(-time.Second)
+            } else if asOf, err = time.Parse(time.RFC3339, v); err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date"})
+                return
+            }
+        }
+        quote, err := services.LoanPayoff(db, loan.ID, asOf)
+        if err != nil {
+            serviceError(c, err)
             return
         }
-
-        // Optionally, log a transaction in your transactions table
-        // e.g., "loan repayment" if you want a record
-        // We can do that if you want a separate model referencing "loans" or do it in "transactions" with a new field.
-        // For simplicity, let's skip or do something simple:
-        // (Assuming you have a Transaction model, you can reuse that or create a new type)
-
-        c.JSON(http.StatusOK, loan)
+        c.JSON(http.StatusOK, quote)
     }
 }
 
+// SetRepaymentAccountHandler - the borrower or staff choose the account due
+// installments are collected from by direct debit
+func SetRepaymentAccountHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        loan, ok := loadLoan(c, db)
+        if !ok {
+            return
+        }
+        var input struct {
+            AccountID uint `json:"account_id" binding:"required"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+        update, err := services.SetRepaymentAccount(db, loan.ID, &input.AccountID)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "loan", update.Loan.ID)
+        audit.SetChange(c, update.Before, update.Loan)
+        c.JSON(http.StatusOK, update.Loan)
+    }
+}
 
+// RemoveRepaymentAccountHandler - the borrower or staff stop direct debit;
+// installments then have to be repaid by hand
+func RemoveRepaymentAccountHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        loan, ok := loadLoan(c, db)
+        if !ok {
+            return
+        }
+        update, err := services.SetRepaymentAccount(db, loan.ID, nil)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "loan", update.Loan.ID)
+        audit.SetChange(c, update.Before, update.Loan)
+        c.JSON(http.StatusOK, update.Loan)
+    }
+}
 
+// ListLoanCollectionsHandler returns a loan's direct debit attempts, newest
+// first, to its borrower or staff.
+func ListLoanCollectionsHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        loan, ok := loadLoan(c, db)
+        if !ok {
+            return
+        }
+        collections := []models.LoanCollection{}
+        if err := db.Where("loan_id = ?", loan.ID).Order("created_at DESC").Find(&collections).Error; err != nil {
+            internalError(c, "Could not fetch collections", err)
+            return
+        }
+        c.JSON(http.StatusOK, collections)
+    }
+}
@@ -0,0 +1,127 @@
+// handlers/loan_product.go
+package handlers
+
+import (
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// loanProductInput is the body for creating or replacing a loan product.
+type loanProductInput struct {
+    Code         string  `json:"code" binding:"required"`
+    Name         string  `json:"name" binding:"required"`
+    Description  string  `json:"description"`
+    MinPrincipal float64 `json:"min_principal"`
+    MaxPrincipal float64 `json:"max_principal" binding:"required"`
+    Terms        string  `json:"terms" binding:"required"` // e.g. "12,24,36"
+    MinRate      float64 `json:"min_rate"`
+    MaxRate      float64 `json:"max_rate" binding:"required"`
+    Active       *bool   `json:"active"` // Defaults to true
+    models.LoanFeeTerms
+}
+
+func (in loanProductInput) product() models.LoanProduct {
+    active := in.Active == nil || *in.Active
+    return models.LoanProduct{
+        Code:         in.Code,
+        Name:         in.Name,
+        Description:  in.Description,
+        MinPrincipal: in.MinPrincipal,
+        MaxPrincipal: in.MaxPrincipal,
+        Terms:        in.Terms,
+        MinRate:      in.MinRate,
+        MaxRate:      in.MaxRate,
+        Active:       active,
+        LoanFeeTerms: in.LoanFeeTerms,
+    }
+}
+
+// ListLoanProductsHandler lists the active loan products, or all of them with
+// ?include_inactive=true.
+func ListLoanProductsHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        query := db.Order("code")
+        if c.Query("include_inactive") != "true" {
+            query = query.Where("active = ?", true)
+        }
+        var products []models.LoanProduct
+        if err := query.Find(&products).Error; err != nil {
+            internalError(c, "Could not fetch loan products", err)
+            return
+        }
+        c.JSON(http.StatusOK, products)
+    }
+}
+
+// GetLoanProductHandler returns one loan product.
+func GetLoanProductHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        id, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan product ID"})
+            return
+        }
+        var product models.LoanProduct
+        if err := db.First(&product, id).Error; err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Loan product not found"})
+            return
+        }
+        c.JSON(http.StatusOK, product)
+    }
+}
+
+// CreateLoanProductHandler - staff add a product to the loan catalog
+func CreateLoanProductHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input loanProductInput
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+        product := input.product()
+        if err := services.CreateLoanProduct(db, &product); err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "loan_product", product.ID)
+        audit.SetChange(c, nil, product)
+        c.JSON(http.StatusCreated, product)
+    }
+}
+
+// UpdateLoanProductHandler - staff replace a product's terms. Existing loans
+// keep the terms they were taken out under.
+func UpdateLoanProductHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        id, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan product ID"})
+            return
+        }
+        var input loanProductInput
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+        product := input.product()
+        before, err := services.UpdateLoanProduct(db, uint(id), &product)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "loan_product", product.ID)
+        audit.SetChange(c, before, product)
+        c.JSON(http.StatusOK, product)
+    }
+}
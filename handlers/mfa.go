@@ -0,0 +1,271 @@
+// handlers/mfa.go
+package handlers
+
+import (
+    "errors"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+    "github.com/bhushangupta162/bank_management/utils"
+)
+
+// currentUserID returns the user ID that AuthMiddleware stored on the context.
+func currentUserID(c *gin.Context) (uint, bool) {
+    v, ok := c.Get("user_id")
+    if !ok {
+        return 0, false
+    }
+    id, ok := v.(uint)
+    return id, ok && id > 0
+}
+
+// loadCurrentUser fetches the authenticated user, writing an error response on failure.
+func loadCurrentUser(c *gin.Context, db *gorm.DB) (*models.User, bool) {
+    userID, ok := currentUserID(c)
+    if !ok {
+        c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+        return nil, false
+    }
+    var user models.User
+    if err := db.First(&user, userID).Error; err != nil {
+        c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+        return nil, false
+    }
+    return &user, true
+}
+
+// issueRecoveryCodes replaces the user's recovery codes and returns the new
+// plaintext codes. They are shown to the user exactly once.
+func issueRecoveryCodes(tx *gorm.DB, userID uint, count int) ([]string, error) {
+    if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+        return nil, err
+    }
+    codes := make([]string, 0, count)
+    for i := 0; i < count; i++ {
+        code, err := utils.GenerateRecoveryCode()
+        if err != nil {
+            return nil, err
+        }
+        if err := tx.Create(&models.RecoveryCode{UserID: userID, CodeHash: utils.HashToken(code)}).Error; err != nil {
+            return nil, err
+        }
+        codes = append(codes, code)
+    }
+    return codes, nil
+}
+
+// EnrollMFAHandler starts TOTP enrollment and returns the secret and provisioning URI.
+// MFA is not enforced until the user confirms a code with ConfirmMFAHandler.
+func EnrollMFAHandler(db *gorm.DB, cfg config.MFAConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        if user.MFAEnabled {
+            c.JSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is already enabled"})
+            return
+        }
+
+        secret, err := utils.GenerateTOTPSecret()
+        if err != nil {
+            internalError(c, "Could not generate secret", err)
+            return
+        }
+        if err := db.Model(user).Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": 0}).Error; err != nil {
+            internalError(c, "Could not start enrollment", err)
+            return
+        }
+
+        c.JSON(http.StatusOK, gin.H{
+            "secret":           secret,
+            "provisioning_uri": utils.TOTPProvisioningURI(secret, cfg.Issuer, user.Email),
+        })
+    }
+}
+
+// ConfirmMFAHandler enables MFA once the user proves their authenticator works,
+// and returns a fresh set of recovery codes.
+func ConfirmMFAHandler(db *gorm.DB, cfg config.MFAConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input struct {
+            Code string `json:"code" binding:"required"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        if user.MFAEnabled {
+            c.JSON(http.StatusConflict, gin.H{"error": "Two-factor authentication is already enabled"})
+            return
+        }
+        if user.TOTPSecret == "" {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Start enrollment first"})
+            return
+        }
+        if !services.VerifyTOTP(db, user, input.Code) {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid code"})
+            return
+        }
+
+        var codes []string
+        err := db.Transaction(func(tx *gorm.DB) error {
+            if err := tx.Model(user).Update("mfa_enabled", true).Error; err != nil {
+                return err
+            }
+            var err error
+            codes, err = issueRecoveryCodes(tx, user.ID, cfg.RecoveryCodeCount)
+            return err
+        })
+        if err != nil {
+            internalError(c, "Could not enable two-factor authentication", err)
+            return
+        }
+
+        c.JSON(http.StatusOK, gin.H{
+            "message":        "Two-factor authentication enabled",
+            "recovery_codes": codes,
+        })
+    }
+}
+
+// RegenerateRecoveryCodesHandler invalidates all recovery codes and issues new ones.
+func RegenerateRecoveryCodesHandler(db *gorm.DB, cfg config.MFAConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input struct {
+            Code string `json:"code" binding:"required"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        if !user.MFAEnabled {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Two-factor authentication is not enabled"})
+            return
+        }
+        if !services.VerifyTOTP(db, user, input.Code) {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid code"})
+            return
+        }
+
+        var codes []string
+        err := db.Transaction(func(tx *gorm.DB) error {
+            var err error
+            codes, err = issueRecoveryCodes(tx, user.ID, cfg.RecoveryCodeCount)
+            return err
+        })
+        if err != nil {
+            internalError(c, "Could not generate recovery codes", err)
+            return
+        }
+
+        c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+    }
+}
+
+// DisableMFAHandler turns MFA off after verifying a TOTP or recovery code.
+func DisableMFAHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input struct {
+            Code         string `json:"code"`
+            RecoveryCode string `json:"recovery_code"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        if !user.MFAEnabled {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Two-factor authentication is not enabled"})
+            return
+        }
+        if !services.VerifyTOTP(db, user, input.Code) && !services.ConsumeRecoveryCode(db, user.ID, input.RecoveryCode) {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid code"})
+            return
+        }
+
+        err := db.Transaction(func(tx *gorm.DB) error {
+            if err := tx.Model(user).Updates(map[string]interface{}{
+                "mfa_enabled":    false,
+                "totp_secret":    "",
+                "totp_last_step": 0,
+            }).Error; err != nil {
+                return err
+            }
+            return tx.Unscoped().Where("user_id = ?", user.ID).Delete(&models.RecoveryCode{}).Error
+        })
+        if err != nil {
+            internalError(c, "Could not disable two-factor authentication", err)
+            return
+        }
+
+        c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+    }
+}
+
+// MFALoginHandler is the second login step: it exchanges the MFA challenge
+// token from LoginHandler plus a TOTP or recovery code for an access token.
+func MFALoginHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input struct {
+            MFAToken     string `json:"mfa_token" binding:"required"`
+            Code         string `json:"code"`
+            RecoveryCode string `json:"recovery_code"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        tokenString, err := services.LoginMFA(db, input.MFAToken, input.Code, input.RecoveryCode)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        c.JSON(http.StatusOK, gin.H{"token": tokenString})
+    }
+}
+
+// requireStepUpMFA enforces a fresh TOTP code for transfers above the configured
+// threshold. It writes the error response and returns false when the check fails.
+func requireStepUpMFA(c *gin.Context, db *gorm.DB, cfg config.MFAConfig, userID uint, amount float64, code string) bool {
+    if err := services.CheckStepUpMFA(db, cfg, userID, amount, code); err != nil {
+        stepUpError(c, err)
+        return false
+    }
+    return true
+}
+
+// stepUpError is serviceError plus the "mfa_required" flag clients use to ask
+// the user for a code.
+func stepUpError(c *gin.Context, err error) {
+    if errors.Is(err, services.ErrMFARequired) {
+        c.JSON(http.StatusUnauthorized, gin.H{"error": services.ErrMFARequired.Message, "mfa_required": true})
+        return
+    }
+    serviceError(c, err)
+}
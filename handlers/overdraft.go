@@ -0,0 +1,49 @@
+// handlers/overdraft.go
+package handlers
+
+import (
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// SetOverdraftHandler - staff grant, change or remove (limit 0) an account's
+// overdraft. Omitted interest_rate and fee take the configured defaults.
+func SetOverdraftHandler(db *gorm.DB, cfg config.OverdraftConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        accountID, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+            return
+        }
+        var input struct {
+            Limit        *float64 `json:"limit" binding:"required"`
+            InterestRate *float64 `json:"interest_rate"`
+            Fee          *float64 `json:"fee"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        update, err := services.SetOverdraft(db, cfg, uint(accountID), services.OverdraftTerms{
+            Limit:        *input.Limit,
+            InterestRate: input.InterestRate,
+            Fee:          input.Fee,
+        })
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "account", update.Account.ID)
+        audit.SetChange(c, update.Before, update.Account)
+        c.JSON(http.StatusOK, update.Account)
+    }
+}
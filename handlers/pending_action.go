@@ -0,0 +1,112 @@
+// handlers/pending_action.go
+package handlers
+
+import (
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// ListPendingActionsHandler lists the actions the current user proposed, or
+// every action for staff. Optional filters: ?status= and ?kind=.
+func ListPendingActionsHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+
+        query := db.Model(&models.PendingAction{})
+        if !user.IsStaff() {
+            query = query.Where("maker_id = ?", user.ID)
+        }
+        if status := c.Query("status"); status != "" {
+            query = query.Where("status = ?", status)
+        }
+        if kind := c.Query("kind"); kind != "" {
+            query = query.Where("kind = ?", kind)
+        }
+        var actions []models.PendingAction
+        if err := query.Order("created_at DESC").Find(&actions).Error; err != nil {
+            internalError(c, "Could not fetch pending actions", err)
+            return
+        }
+        c.JSON(http.StatusOK, actions)
+    }
+}
+
+// GetPendingActionHandler returns one action to its maker or to staff.
+func GetPendingActionHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        id, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pending action ID"})
+            return
+        }
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        var action models.PendingAction
+        if err := db.First(&action, id).Error; err != nil || (action.MakerID != user.ID && !user.IsStaff()) {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Pending action not found"})
+            return
+        }
+        c.JSON(http.StatusOK, action)
+    }
+}
+
+// ApprovePendingActionHandler - a checker approves an action, which then runs.
+// The checker must not be the maker.
+func ApprovePendingActionHandler(db *gorm.DB, limitsCfg config.LimitsConfig) gin.HandlerFunc {
+    env := services.ActionEnv{Limits: limitsCfg}
+    return decidePendingAction(db, func(db *gorm.DB, actionID, checkerID uint, note string) (*services.ActionDecision, error) {
+        return services.ApproveAction(db, env, actionID, checkerID, note)
+    })
+}
+
+// RejectPendingActionHandler - a checker turns an action down.
+func RejectPendingActionHandler(db *gorm.DB) gin.HandlerFunc {
+    return decidePendingAction(db, services.RejectAction)
+}
+
+// decidePendingAction is the shared body of the approve and reject handlers.
+// An approved action whose operation was refused is still recorded (as failed)
+// and the refusal is returned as the error.
+func decidePendingAction(db *gorm.DB, decideFn func(db *gorm.DB, actionID, checkerID uint, note string) (*services.ActionDecision, error)) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        id, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pending action ID"})
+            return
+        }
+        var input struct {
+            Note string `json:"note"`
+        }
+        if !bindOptionalJSON(c, &input) {
+            return
+        }
+
+        checkerID, _ := currentUserID(c)
+        decision, err := decideFn(db, uint(id), checkerID, input.Note)
+        if decision != nil {
+            audit.SetEntity(c, "pending_action", decision.Action.ID)
+            audit.SetChange(c, decision.Before, decision.Action)
+        }
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        c.JSON(http.StatusOK, gin.H{"action": decision.Action, "result": decision.Result})
+    }
+}
@@ -0,0 +1,95 @@
+// handlers/reversal.go
+package handlers
+
+import (
+    "errors"
+    "io"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// bindOptionalJSON binds a JSON body that may be left out entirely. It writes
+// the error response and returns false for a malformed body.
+func bindOptionalJSON(c *gin.Context, v interface{}) bool {
+    if err := c.ShouldBindJSON(v); err != nil && !errors.Is(err, io.EOF) {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return false
+    }
+    return true
+}
+
+// ReverseTransactionHandler undoes a deposit, withdrawal or transfer with
+// compensating entries. Staff reversals run at once; a customer (owner of one
+// of the accounts involved) only proposes one, which staff must approve under
+// /pending-actions.
+func ReverseTransactionHandler(db *gorm.DB, approvalCfg config.ApprovalConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        transactionID, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+            return
+        }
+        var input struct {
+            Reason        string `json:"reason"`
+            AllowNegative bool   `json:"allow_negative"` // Staff only
+        }
+        if !bindOptionalJSON(c, &input) {
+            return
+        }
+
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        if !user.IsStaff() && !ownsTransaction(db, uint(transactionID), user.ID) {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+            return
+        }
+
+        req := services.ReversalRequest{TransactionID: uint(transactionID), Reason: input.Reason, AllowNegative: input.AllowNegative}
+        if !user.IsStaff() {
+            if input.AllowNegative {
+                c.JSON(http.StatusForbidden, gin.H{"error": "Only staff can allow a negative balance"})
+                return
+            }
+            action, err := services.RequestReversal(db, approvalCfg, req, user.ID)
+            if err != nil {
+                serviceError(c, err)
+                return
+            }
+            audit.SetEntity(c, "pending_action", action.ID)
+            audit.SetChange(c, nil, action)
+            c.JSON(http.StatusAccepted, action)
+            return
+        }
+
+        result, err := services.ReverseTransaction(db, req)
+        if err != nil {
+            serviceError(c, err)
+            return
+        }
+        audit.SetEntity(c, "transaction", uint(transactionID))
+        audit.SetChange(c, result.Original, result.Reversals)
+        c.JSON(http.StatusCreated, result)
+    }
+}
+
+// ownsTransaction reports whether the user owns the account of the transaction
+// or, for a transfer, of its other leg.
+func ownsTransaction(db *gorm.DB, transactionID, userID uint) bool {
+    var count int64
+    err := db.Model(&models.Transaction{}).
+        Joins("JOIN accounts ON accounts.id = transactions.account_id").
+        Where("(transactions.id = ? OR transactions.counterpart_id = ?) AND accounts.user_id = ?", transactionID, transactionID, userID).
+        Count(&count).Error
+    return err == nil && count > 0
+}
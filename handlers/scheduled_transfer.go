@@ -0,0 +1,320 @@
+// handlers/scheduled_transfer.go
+package handlers
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/services"
+)
+
+// parseScheduleTime accepts a date ("2026-11-01", midnight UTC) or an RFC 3339 timestamp.
+func parseScheduleTime(s string) (time.Time, error) {
+    if t, err := time.Parse("2006-01-02", s); err == nil {
+        return t, nil
+    }
+    return time.Parse(time.RFC3339, s)
+}
+
+// loadOwnedSchedule fetches a scheduled transfer that the current user created
+// (or any, for staff). It writes the error response on failure.
+func loadOwnedSchedule(c *gin.Context, db *gorm.DB) (*models.ScheduledTransfer, bool) {
+    id, err := strconv.Atoi(c.Param("id"))
+    if err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scheduled transfer ID"})
+        return nil, false
+    }
+    user, ok := loadCurrentUser(c, db)
+    if !ok {
+        return nil, false
+    }
+    var st models.ScheduledTransfer
+    if err := db.First(&st, id).Error; err != nil || (st.UserID != user.ID && !user.IsStaff()) {
+        c.JSON(http.StatusNotFound, gin.H{"error": "Scheduled transfer not found"})
+        return nil, false
+    }
+    return &st, true
+}
+
+// CreateScheduledTransferHandler creates a one-off future or recurring transfer
+// from one of the current user's accounts. Amounts that would need approval as
+// a one-off transfer are refused, since nobody is there to approve each run.
+func CreateScheduledTransferHandler(db *gorm.DB, mfaCfg config.MFAConfig, emailCfg config.EmailConfig, numCfg config.AccountNumberConfig, approvalCfg config.ApprovalConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input struct {
+            FromAccountID uint    `json:"from_account_id"`
+            FromAccount   string  `json:"from_account"` // Account number, IBAN or ID
+            ToAccountID   uint    `json:"to_account_id"`
+            ToAccount     string  `json:"to_account"`
+            Amount        float64 `json:"amount" binding:"required"`
+            Description   string  `json:"description"`
+            Frequency     string  `json:"frequency" binding:"required"` // once, daily, weekly, monthly
+            DayOfMonth    int     `json:"day_of_month"`                 // Monthly; defaults to the start date's day
+            StartDate     string  `json:"start_date" binding:"required"`
+            EndDate       string  `json:"end_date"`
+            MFACode       string  `json:"mfa_code"`
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        var err error
+        if input.FromAccountID, err = services.ResolveAccountID(db, input.FromAccountID, input.FromAccount, numCfg); err != nil {
+            accountRefError(c, "Source", err)
+            return
+        }
+        if input.ToAccountID, err = services.ResolveAccountID(db, input.ToAccountID, input.ToAccount, numCfg); err != nil {
+            accountRefError(c, "Destination", err)
+            return
+        }
+        if input.FromAccountID == input.ToAccountID {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot transfer to the same account"})
+            return
+        }
+        if input.Amount <= 0 {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be positive"})
+            return
+        }
+        if services.TransferNeedsApproval(approvalCfg, input.Amount) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Amount needs approval and cannot be scheduled; make a one-off transfer instead"})
+            return
+        }
+
+        switch input.Frequency {
+        case models.FrequencyOnce, models.FrequencyDaily, models.FrequencyWeekly, models.FrequencyMonthly:
+        default:
+            c.JSON(http.StatusBadRequest, gin.H{"error": "frequency must be once, daily, weekly or monthly"})
+            return
+        }
+
+        startAt, err := parseScheduleTime(input.StartDate)
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date, use YYYY-MM-DD or RFC 3339"})
+            return
+        }
+        // Today is allowed (the first run then happens on the next worker tick)
+        if startAt.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "start_date must not be in the past"})
+            return
+        }
+        var endAt *time.Time
+        if input.EndDate != "" {
+            t, err := parseScheduleTime(input.EndDate)
+            if err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date, use YYYY-MM-DD or RFC 3339"})
+                return
+            }
+            if t.Before(startAt) {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must be after start_date"})
+                return
+            }
+            endAt = &t
+        }
+        if input.Frequency == models.FrequencyMonthly {
+            if input.DayOfMonth == 0 {
+                input.DayOfMonth = startAt.Day()
+            }
+            if input.DayOfMonth < 1 || input.DayOfMonth > 31 {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "day_of_month must be between 1 and 31"})
+                return
+            }
+        } else {
+            input.DayOfMonth = 0
+        }
+
+        // Only the owner of the source account can set up standing orders from it
+        user, ok := loadCurrentUser(c, db)
+        if !ok {
+            return
+        }
+        var source models.Account
+        if err := db.First(&source, input.FromAccountID).Error; err != nil || source.UserID != user.ID {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Source account not found"})
+            return
+        }
+        if !requireDebitable(c, &source) {
+            return
+        }
+        var destination models.Account
+        if err := db.First(&destination, input.ToAccountID).Error; err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Destination account not found"})
+            return
+        }
+        if !requireVerifiedEmail(c, db, emailCfg, user.ID) {
+            return
+        }
+        if !requireStepUpMFA(c, db, mfaCfg, user.ID, input.Amount, input.MFACode) {
+            return
+        }
+
+        st := models.ScheduledTransfer{
+            UserID:        user.ID,
+            FromAccountID: input.FromAccountID,
+            ToAccountID:   input.ToAccountID,
+            Amount:        input.Amount,
+            Description:   input.Description,
+            Frequency:     input.Frequency,
+            DayOfMonth:    input.DayOfMonth,
+            StartAt:       startAt,
+            EndAt:         endAt,
+            Status:        models.ScheduleStatusActive,
+        }
+        first := services.FirstOccurrence(&st)
+        if endAt != nil && first.After(*endAt) {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "No occurrence falls between start_date and end_date"})
+            return
+        }
+        st.NextOccurrence = &first
+        st.NextRunAt = &first
+
+        if err := db.Create(&st).Error; err != nil {
+            internalError(c, "Could not create scheduled transfer", err)
+            return
+        }
+        audit.SetEntity(c, "scheduled_transfer", st.ID)
+        audit.SetChange(c, nil, st)
+        c.JSON(http.StatusCreated, st)
+    }
+}
+
+// ListScheduledTransfersHandler lists the current user's scheduled transfers.
+// An optional ?status= filters by status.
+func ListScheduledTransfersHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        userID, ok := currentUserID(c)
+        if !ok {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+            return
+        }
+
+        query := db.Where("user_id = ?", userID)
+        if status := c.Query("status"); status != "" {
+            query = query.Where("status = ?", status)
+        }
+        var schedules []models.ScheduledTransfer
+        if err := query.Order("created_at DESC").Find(&schedules).Error; err != nil {
+            internalError(c, "Could not fetch scheduled transfers", err)
+            return
+        }
+        c.JSON(http.StatusOK, schedules)
+    }
+}
+
+// GetScheduledTransferHandler returns a scheduled transfer and its execution history.
+func GetScheduledTransferHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        st, ok := loadOwnedSchedule(c, db)
+        if !ok {
+            return
+        }
+        var runs []models.ScheduledTransferRun
+        if err := db.Where("scheduled_transfer_id = ?", st.ID).Order("created_at DESC").Find(&runs).Error; err != nil {
+            internalError(c, "Could not fetch execution history", err)
+            return
+        }
+        c.JSON(http.StatusOK, gin.H{
+            "scheduled_transfer": st,
+            "runs":               runs,
+        })
+    }
+}
+
+// updateScheduleStatus applies a status change to a locked schedule if it is in one of the allowed states.
+func updateScheduleStatus(c *gin.Context, db *gorm.DB, to string, from []string, apply func(st *models.ScheduledTransfer)) {
+    st, ok := loadOwnedSchedule(c, db)
+    if !ok {
+        return
+    }
+
+    before := *st
+    allowed := false
+    err := db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(st, st.ID).Error; err != nil {
+            return err
+        }
+        for _, s := range from {
+            if st.Status == s {
+                allowed = true
+            }
+        }
+        if !allowed {
+            return nil
+        }
+        st.Status = to
+        if apply != nil {
+            apply(st)
+        }
+        return tx.Save(st).Error
+    })
+    if err != nil {
+        internalError(c, "Could not update scheduled transfer", err)
+        return
+    }
+    if !allowed {
+        c.JSON(http.StatusConflict, gin.H{"error": "Scheduled transfer is " + st.Status})
+        return
+    }
+    audit.SetEntity(c, "scheduled_transfer", st.ID)
+    audit.SetChange(c, before, st)
+    c.JSON(http.StatusOK, st)
+}
+
+// PauseScheduledTransferHandler stops a schedule from running until it is resumed.
+func PauseScheduledTransferHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        updateScheduleStatus(c, db, models.ScheduleStatusPaused, []string{models.ScheduleStatusActive}, nil)
+    }
+}
+
+// ResumeScheduledTransferHandler reactivates a paused schedule. Occurrences
+// missed while paused are skipped, not replayed.
+func ResumeScheduledTransferHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        updateScheduleStatus(c, db, models.ScheduleStatusActive, []string{models.ScheduleStatusPaused}, func(st *models.ScheduledTransfer) {
+            now := time.Now()
+            from := services.FirstOccurrence(st)
+            if st.NextOccurrence != nil {
+                from = *st.NextOccurrence
+            }
+            next := from
+            if st.Frequency != models.FrequencyOnce {
+                n := services.NextOccurrenceAfter(st, from, now)
+                if n == nil {
+                    st.Status = models.ScheduleStatusCompleted
+                    st.NextOccurrence = nil
+                    st.NextRunAt = nil
+                    return
+                }
+                next = *n
+            }
+            st.Attempts = 0
+            st.NextOccurrence = &next
+            st.NextRunAt = &next
+        })
+    }
+}
+
+// CancelScheduledTransferHandler stops a schedule permanently.
+func CancelScheduledTransferHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        updateScheduleStatus(c, db, models.ScheduleStatusCancelled, []string{models.ScheduleStatusActive, models.ScheduleStatusPaused}, func(st *models.ScheduledTransfer) {
+            st.NextOccurrence = nil
+            st.NextRunAt = nil
+        })
+    }
+}
@@ -0,0 +1,161 @@
+// handlers/stream.go
+package handlers
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/stream"
+)
+
+// streamBatchSize bounds how many missed transactions are loaded per query on resume.
+const streamBatchSize = 500
+
+// StreamAccountHandler pushes an account's new transactions as Server-Sent
+// Events. The stream starts with a "balance" event, then sends one
+// "transaction" event per committed transaction with its ID as the event ID.
+// A transaction committed late, after a higher ID was sent, is still sent but
+// keeps that higher event ID, so Last-Event-ID never goes backwards. A client
+// that reconnects with Last-Event-ID first gets the transactions it missed
+// from the database, then continues live.
+func StreamAccountHandler(db *gorm.DB, broker stream.Broker, cfg config.StreamConfig) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        ctx := c.Request.Context()
+        db := db.WithContext(ctx)
+        accountID, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid account ID"})
+            return
+        }
+        var lastID uint64
+        resume := c.GetHeader("Last-Event-ID")
+        if resume != "" {
+            if lastID, err = strconv.ParseUint(resume, 10, 64); err != nil {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+                return
+            }
+        }
+
+        var account models.Account
+        if err := db.First(&account, accountID).Error; err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Account not found"})
+            return
+        }
+        if !canAccessAccount(c, db, &account) {
+            return
+        }
+
+        // Subscribe before reading the database, so nothing committed in
+        // between is missed; events the snapshot already covers are skipped below.
+        sub, err := broker.Subscribe(ctx, stream.AccountTopic(account.ID))
+        if err != nil {
+            internalError(c, "Could not open stream", err)
+            return
+        }
+        defer sub.Close()
+
+        var latestID uint64
+        if err := db.First(&account, account.ID).Error; err != nil {
+            internalError(c, "Could not fetch account", err)
+            return
+        }
+        if err := db.Model(&models.Transaction{}).Where("account_id = ?", account.ID).
+            Select("COALESCE(MAX(id), 0)").Scan(&latestID).Error; err != nil {
+            internalError(c, "Could not fetch transactions", err)
+            return
+        }
+        sent := stream.NewSentIDs()
+        if err := sent.MarkRecent(db, account.ID, latestID, time.Now()); err != nil {
+            internalError(c, "Could not fetch transactions", err)
+            return
+        }
+
+        metrics.StreamOpened()
+        defer metrics.StreamClosed()
+
+        c.Header("Content-Type", "text/event-stream")
+        c.Header("Cache-Control", "no-cache")
+        c.Header("Connection", "keep-alive")
+        c.Header("X-Accel-Buffering", "no") // Keep nginx from buffering the stream
+        c.Status(http.StatusOK)
+
+        // A fresh stream starts from the current balance; a resumed one replays
+        // what the client missed, and the balance event marks where replay ended.
+        if resume != "" {
+            if err := replayTransactions(c, db, account.ID, lastID, latestID); err != nil {
+                logging.From(c).Error("could not replay transactions", "account_id", account.ID, "error", err)
+                return
+            }
+        }
+        lastID = latestID
+        balance, _ := json.Marshal(gin.H{"account_id": account.ID, "balance": account.Balance, "currency": account.Currency})
+        writeSSE(c, stream.Event{ID: uint(latestID), Type: stream.EventBalance, Data: balance})
+
+        heartbeat := time.NewTicker(cfg.Heartbeat)
+        defer heartbeat.Stop()
+        for {
+            select {
+            case <-ctx.Done():
+                return
+            case event, ok := <-sub.Events():
+                if !ok {
+                    return // Dropped for falling behind; the client resumes with Last-Event-ID
+                }
+                if sent.Has(event.ID) {
+                    continue
+                }
+                sent.Add(event.ID, time.Now())
+                if uint64(event.ID) > lastID {
+                    lastID = uint64(event.ID)
+                }
+                event.ID = uint(lastID)
+                writeSSE(c, event)
+            case <-heartbeat.C:
+                sent.Prune(time.Now())
+                fmt.Fprint(c.Writer, ": ping\n\n")
+                c.Writer.Flush()
+            }
+        }
+    }
+}
+
+// replayTransactions sends the account's transactions with an ID in
+// (afterID, untilID] in ID order.
+func replayTransactions(c *gin.Context, db *gorm.DB, accountID uint, afterID, untilID uint64) error {
+    for afterID < untilID {
+        var batch []models.Transaction
+        if err := db.Where("account_id = ? AND id > ? AND id <= ?", accountID, afterID, untilID).
+            Order("id").Limit(streamBatchSize).Find(&batch).Error; err != nil {
+            return err
+        }
+        if len(batch) == 0 {
+            break
+        }
+        for i := range batch {
+            data, err := json.Marshal(batch[i])
+            if err != nil {
+                return err
+            }
+            writeSSE(c, stream.Event{ID: batch[i].ID, Type: stream.EventTransaction, Data: data})
+            afterID = uint64(batch[i].ID)
+        }
+    }
+    return nil
+}
+
+// writeSSE writes one event and flushes it to the client. Payloads are
+// single-line JSON, so one data field is enough.
+func writeSSE(c *gin.Context, event stream.Event) {
+    fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+    c.Writer.Flush()
+}
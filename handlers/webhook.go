@@ -0,0 +1,177 @@
+// handlers/webhook.go
+package handlers
+
+import (
+    "net/http"
+    "net/url"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/utils"
+)
+
+// webhookEventTypes lists the events that can be subscribed to.
+var webhookEventTypes = map[string]bool{
+    "*":                            true,
+    models.EventTransactionCreated: true,
+    models.EventLoanStatusChanged:  true,
+    models.EventLoanLateFeeCharged: true,
+    models.EventAccountFrozen:      true,
+    models.EventAccountDormant:     true,
+    models.EventAccountClosed:      true,
+    models.EventAccountReactivated: true,
+    models.EventHoldCreated:        true,
+    models.EventHoldCaptured:       true,
+    models.EventHoldReleased:       true,
+}
+
+// CreateWebhookHandler registers a webhook endpoint. The signing secret is
+// only returned in this response.
+func CreateWebhookHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var input struct {
+            URL    string   `json:"url" binding:"required"`
+            Events []string `json:"events" binding:"required,min=1"` // e.g. ["transaction.created"] or ["*"]
+        }
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+
+        u, err := url.Parse(input.URL)
+        if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "url must be an absolute http(s) URL"})
+            return
+        }
+        for _, e := range input.Events {
+            if !webhookEventTypes[e] {
+                c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown event type: " + e})
+                return
+            }
+        }
+
+        secret, err := utils.GenerateRandomToken(32)
+        if err != nil {
+            internalError(c, "Could not generate secret", err)
+            return
+        }
+        userID, _ := currentUserID(c)
+        sub := models.WebhookSubscription{
+            UserID:     userID,
+            URL:        input.URL,
+            Secret:     secret,
+            EventTypes: strings.Join(input.Events, ","),
+            Active:     true,
+        }
+        if err := db.Create(&sub).Error; err != nil {
+            internalError(c, "Could not create webhook", err)
+            return
+        }
+        audit.SetEntity(c, "webhook", sub.ID) // Secret is json:"-", so it is not in the snapshot
+        audit.SetChange(c, nil, sub)
+
+        c.JSON(http.StatusCreated, gin.H{
+            "webhook": sub,
+            "secret":  secret, // Used to verify the X-Webhook-Signature header; not shown again
+        })
+    }
+}
+
+// ListWebhooksHandler lists all webhook subscriptions.
+func ListWebhooksHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        var subs []models.WebhookSubscription
+        if err := db.Order("id").Find(&subs).Error; err != nil {
+            internalError(c, "Could not fetch webhooks", err)
+            return
+        }
+        c.JSON(http.StatusOK, subs)
+    }
+}
+
+// DeleteWebhookHandler removes a subscription. Pending deliveries to it go to the dead-letter list.
+func DeleteWebhookHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        id, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+            return
+        }
+        res := db.Delete(&models.WebhookSubscription{}, id)
+        if res.Error != nil {
+            internalError(c, "Could not delete webhook", res.Error)
+            return
+        }
+        if res.RowsAffected == 0 {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+            return
+        }
+        audit.SetEntity(c, "webhook", uint(id))
+        c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted"})
+    }
+}
+
+// ListWebhookDeliveriesHandler lists deliveries, newest first. Filter with
+// ?status=dead for the dead-letter list and ?webhook_id= for one subscription.
+func ListWebhookDeliveriesHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        query := db.Model(&models.WebhookDelivery{})
+        if status := c.Query("status"); status != "" {
+            query = query.Where("status = ?", status)
+        }
+        if id := c.Query("webhook_id"); id != "" {
+            query = query.Where("subscription_id = ?", id)
+        }
+
+        var deliveries []models.WebhookDelivery
+        if err := query.Order("id DESC").Limit(200).Find(&deliveries).Error; err != nil {
+            internalError(c, "Could not fetch deliveries", err)
+            return
+        }
+        c.JSON(http.StatusOK, deliveries)
+    }
+}
+
+// RedeliverWebhookHandler queues a delivery again, e.g. from the dead-letter
+// list once the receiver is fixed. The attempt counter starts over.
+func RedeliverWebhookHandler(db *gorm.DB) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        id, err := strconv.Atoi(c.Param("id"))
+        if err != nil {
+            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+            return
+        }
+
+        var delivery models.WebhookDelivery
+        if err := db.First(&delivery, id).Error; err != nil {
+            c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+            return
+        }
+        var sub models.WebhookSubscription
+        if err := db.First(&sub, delivery.SubscriptionID).Error; err != nil || !sub.Active {
+            c.JSON(http.StatusConflict, gin.H{"error": "Webhook subscription no longer exists"})
+            return
+        }
+
+        delivery.Status = models.DeliveryStatusPending
+        delivery.Attempts = 0
+        delivery.NextAttemptAt = time.Now()
+        delivery.LastError = ""
+        if err := db.Save(&delivery).Error; err != nil {
+            internalError(c, "Could not queue delivery", err)
+            return
+        }
+        c.JSON(http.StatusAccepted, delivery)
+    }
+}
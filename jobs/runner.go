@@ -0,0 +1,51 @@
+// jobs/runner.go
+package jobs
+
+import (
+    "context"
+    "log/slog"
+    "time"
+
+    "go.opentelemetry.io/otel/codes"
+
+    "github.com/bhushangupta162/bank_management/tracing"
+)
+
+// Every runs fn immediately and then on every tick until ctx is cancelled.
+// Errors are logged and the job keeps running; a panic in fn is recovered
+// so one bad run cannot take the API down. A non-positive interval (which
+// time.NewTicker would panic on) is logged and the job is not started.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+    if interval <= 0 {
+        slog.Error("job not started: interval must be positive", "job", name, "interval", interval)
+        return
+    }
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+
+    for {
+        runOnce(ctx, name, fn)
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+// runOnce runs fn in its own trace so a slow run can be inspected like a request.
+func runOnce(ctx context.Context, name string, fn func(ctx context.Context) error) {
+    ctx, span := tracing.Tracer.Start(ctx, "job "+name)
+    defer span.End()
+    defer func() {
+        if r := recover(); r != nil {
+            span.SetStatus(codes.Error, "panic")
+            slog.Error("job panicked", "job", name, "panic", r)
+        }
+    }()
+    if err := fn(ctx); err != nil {
+        span.RecordError(err)
+        span.SetStatus(codes.Error, err.Error())
+        slog.Error("job failed", "job", name, "error", err)
+    }
+}
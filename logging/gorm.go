@@ -0,0 +1,65 @@
+// logging/gorm.go
+package logging
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log/slog"
+    "time"
+
+    "gorm.io/gorm"
+    gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger sends GORM logs to slog using the request-scoped logger when the
+// query carries a context. SQL is logged with placeholders instead of bound
+// values (see ParamsFilter), so password hashes, TOTP secrets and token hashes
+// never reach the logs, and only at debug level.
+type GormLogger struct {
+    SlowThreshold time.Duration
+}
+
+// NewGormLogger returns a GORM logger that reports errors and slow queries.
+func NewGormLogger() *GormLogger {
+    return &GormLogger{SlowThreshold: 200 * time.Millisecond}
+}
+
+// LogMode is a no-op; the level is controlled by the slog handler.
+func (l *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface { return l }
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+    FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+    FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+    FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+// ParamsFilter drops the bound values from the SQL text GORM builds for
+// Trace, leaving the $n placeholders.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+    return sql, nil
+}
+
+// Trace logs failed queries (except "record not found") and slow queries.
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+    logger := FromContext(ctx)
+    elapsed := time.Since(begin)
+
+    switch {
+    case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+        logger.ErrorContext(ctx, "query failed", "error", err, "elapsed_ms", elapsed.Milliseconds())
+    case l.SlowThreshold > 0 && elapsed > l.SlowThreshold:
+        logger.WarnContext(ctx, "slow query", "elapsed_ms", elapsed.Milliseconds())
+    }
+
+    if logger.Enabled(ctx, slog.LevelDebug) {
+        sql, rows := fc()
+        logger.DebugContext(ctx, "query", "sql", sql, "rows", rows, "elapsed_ms", elapsed.Milliseconds())
+    }
+}
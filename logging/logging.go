@@ -0,0 +1,76 @@
+// logging/logging.go
+package logging
+
+import (
+    "context"
+    "io"
+    "log/slog"
+    "os"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/bhushangupta162/bank_management/config"
+)
+
+type ctxKey struct{}
+
+// New builds the application logger. Every record passes through Redact, so
+// secrets are masked no matter which package logs them.
+func New(cfg config.LogConfig) *slog.Logger {
+    return NewWithWriter(os.Stdout, cfg)
+}
+
+// NewWithWriter is New with a custom output, e.g. a buffer in tests.
+func NewWithWriter(w io.Writer, cfg config.LogConfig) *slog.Logger {
+    opts := &slog.HandlerOptions{
+        Level:       parseLevel(cfg.Level),
+        ReplaceAttr: Redact,
+    }
+    if strings.EqualFold(cfg.Format, "text") {
+        return slog.New(slog.NewTextHandler(w, opts))
+    }
+    return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+func parseLevel(level string) slog.Level {
+    switch strings.ToLower(level) {
+    case "debug":
+        return slog.LevelDebug
+    case "warn", "warning":
+        return slog.LevelWarn
+    case "error":
+        return slog.LevelError
+    default:
+        return slog.LevelInfo
+    }
+}
+
+// WithContext returns a copy of ctx carrying the logger.
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+    return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger, or the default logger when
+// ctx has none (background jobs, startup).
+func FromContext(ctx context.Context) *slog.Logger {
+    if ctx != nil {
+        if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+            return logger
+        }
+    }
+    return slog.Default()
+}
+
+// From returns the logger for a gin request. It already carries the request
+// ID and, after AuthMiddleware, the user ID.
+func From(c *gin.Context) *slog.Logger {
+    return FromContext(c.Request.Context())
+}
+
+// AddAttrs attaches attributes to the request-scoped logger for the rest of
+// the request, e.g. the user ID once the token has been checked.
+func AddAttrs(c *gin.Context, args ...any) {
+    logger := From(c).With(args...)
+    c.Request = c.Request.WithContext(WithContext(c.Request.Context(), logger))
+}
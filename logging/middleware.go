@@ -0,0 +1,97 @@
+// logging/middleware.go
+package logging
+
+import (
+    "log/slog"
+    "net/http"
+    "regexp"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/trace"
+
+    "github.com/bhushangupta162/bank_management/utils"
+)
+
+// RequestIDHeader carries the correlation ID in requests and responses.
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits caller-supplied IDs to something safe to log and echo back.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// RequestIDFrom returns the caller's request ID if it is safe to use, or a new one.
+func RequestIDFrom(incoming string) string {
+    if validRequestID.MatchString(incoming) {
+        return incoming
+    }
+    generated, err := utils.GenerateRandomToken(16)
+    if err != nil {
+        generated = time.Now().UTC().Format("20060102T150405.000000000")
+    }
+    return generated
+}
+
+// RequestID honors an incoming X-Request-ID or creates one, echoes it in the
+// response and attaches a logger carrying it to the request context.
+func RequestID(logger *slog.Logger) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        id := RequestIDFrom(c.GetHeader(RequestIDHeader))
+
+        c.Set("request_id", id)
+        c.Header(RequestIDHeader, id)
+        reqLogger := logger.With("request_id", id)
+        if sc := trace.SpanContextFromContext(c.Request.Context()); sc.IsValid() {
+            // Correlates log lines with the trace started by the tracing middleware
+            reqLogger = reqLogger.With("trace_id", sc.TraceID().String())
+            trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("request.id", id))
+        }
+        ctx := WithContext(c.Request.Context(), reqLogger)
+        c.Request = c.Request.WithContext(ctx)
+
+        c.Next()
+    }
+}
+
+// AccessLog writes one structured line per request. The route pattern is
+// logged instead of the raw path, which may contain account numbers.
+func AccessLog() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        start := time.Now()
+        c.Next()
+
+        route := c.FullPath()
+        if route == "" {
+            route = "unmatched"
+        }
+        status := c.Writer.Status()
+        level := slog.LevelInfo
+        switch {
+        case status >= http.StatusInternalServerError:
+            level = slog.LevelError
+        case status >= http.StatusBadRequest:
+            level = slog.LevelWarn
+        }
+
+        attrs := []any{
+            "method", c.Request.Method,
+            "route", route,
+            "status", status,
+            "latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+            "ip", c.ClientIP(),
+            "bytes", c.Writer.Size(),
+        }
+        if len(c.Errors) > 0 {
+            attrs = append(attrs, "errors", c.Errors.String())
+        }
+        From(c).Log(c.Request.Context(), level, "request", attrs...)
+    }
+}
+
+// Recovery turns a panic into a 500 and logs it with the request's logger.
+func Recovery() gin.HandlerFunc {
+    return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+        From(c).Error("panic recovered", "panic", recovered)
+        c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+    })
+}
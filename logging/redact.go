@@ -0,0 +1,79 @@
+// logging/redact.go
+package logging
+
+import (
+    "log/slog"
+    "regexp"
+    "strings"
+)
+
+// Redacted replaces the value of sensitive attributes.
+const Redacted = "[REDACTED]"
+
+// secretKeys are attribute names whose values are never logged.
+var secretKeys = map[string]bool{
+    "password":      true,
+    "new_password":  true,
+    "token":         true,
+    "mfa_token":     true,
+    "access_token":  true,
+    "authorization": true,
+    "secret":        true,
+    "totp_secret":   true,
+    "code":          true,
+    "mfa_code":      true,
+    "recovery_code": true,
+    "signature":     true,
+}
+
+// accountKeys are attribute names holding account numbers or IBANs; only the
+// last four characters are kept.
+var accountKeys = map[string]bool{
+    "account_number": true,
+    "iban":           true,
+    "from_account":   true,
+    "to_account":     true,
+}
+
+// Secrets embedded in free text, e.g. links in logged emails or error strings.
+var (
+    tokenParam   = regexp.MustCompile(`(?i)((?:token|secret|password|code)=)[^&\s"]+`)
+    bearerToken  = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-_.=]+`)
+    ibanOrNumber = regexp.MustCompile(`\b[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}\b`)
+)
+
+// Redact is a slog ReplaceAttr function that masks secrets and account numbers.
+func Redact(groups []string, a slog.Attr) slog.Attr {
+    key := strings.ToLower(a.Key)
+    switch {
+    case secretKeys[key]:
+        return slog.String(a.Key, Redacted)
+    case accountKeys[key]:
+        return slog.String(a.Key, MaskAccountNumber(a.Value.String()))
+    }
+
+    switch a.Value.Kind() {
+    case slog.KindString:
+        return slog.String(a.Key, ScrubText(a.Value.String()))
+    case slog.KindAny:
+        if err, ok := a.Value.Any().(error); ok {
+            return slog.String(a.Key, ScrubText(err.Error()))
+        }
+    }
+    return a
+}
+
+// ScrubText removes tokens, bearer credentials and IBANs from free text.
+func ScrubText(s string) string {
+    s = tokenParam.ReplaceAllString(s, "${1}"+Redacted)
+    s = bearerToken.ReplaceAllString(s, "${1}"+Redacted)
+    return ibanOrNumber.ReplaceAllStringFunc(s, MaskAccountNumber)
+}
+
+// MaskAccountNumber keeps the last four characters, e.g. "******7890".
+func MaskAccountNumber(s string) string {
+    if len(s) <= 4 {
+        return strings.Repeat("*", len(s))
+    }
+    return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
@@ -0,0 +1,48 @@
+// mailer/mailer.go
+package mailer
+
+import (
+    "fmt"
+    "log/slog"
+
+    "github.com/bhushangupta162/bank_management/config"
+)
+
+// Message is a plain-text email.
+type Message struct {
+    To      string
+    Subject string
+    Body    string
+}
+
+// Mailer sends email. Handlers only depend on this interface so the transport
+// can be swapped (SMTP in production, memory or files in tests and local dev).
+type Mailer interface {
+    Send(msg Message) error
+}
+
+// New builds the Mailer selected by cfg.Driver ("smtp", "file", "memory" or "log").
+func New(cfg config.MailConfig) (Mailer, error) {
+    switch cfg.Driver {
+    case "smtp":
+        return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPassword, cfg.From), nil
+    case "file":
+        return NewFileMailer(cfg.Dir, cfg.From)
+    case "memory":
+        return NewMemoryMailer(), nil
+    case "log", "":
+        return LogMailer{}, nil
+    default:
+        return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+    }
+}
+
+// LogMailer writes messages to the application log. Useful for local development;
+// tokens in links are redacted by the logger, so use the "file" driver to follow them.
+type LogMailer struct{}
+
+// Send logs the message instead of delivering it.
+func (LogMailer) Send(msg Message) error {
+    slog.Info("mail", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+    return nil
+}
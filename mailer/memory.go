@@ -0,0 +1,60 @@
+// mailer/memory.go
+package mailer
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// MemoryMailer keeps sent messages in memory so tests can inspect them.
+type MemoryMailer struct {
+    mu       sync.Mutex
+    messages []Message
+}
+
+// NewMemoryMailer creates an empty in-memory mailer.
+func NewMemoryMailer() *MemoryMailer {
+    return &MemoryMailer{}
+}
+
+// Send records the message.
+func (m *MemoryMailer) Send(msg Message) error {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.messages = append(m.messages, msg)
+    return nil
+}
+
+// Messages returns a copy of every message sent so far.
+func (m *MemoryMailer) Messages() []Message {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    out := make([]Message, len(m.messages))
+    copy(out, m.messages)
+    return out
+}
+
+// FileMailer writes each message to its own .eml file in a directory.
+type FileMailer struct {
+    dir  string
+    from string
+    seq  atomic.Uint64
+}
+
+// NewFileMailer creates the output directory if needed.
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+    if err := os.MkdirAll(dir, 0o750); err != nil {
+        return nil, err
+    }
+    return &FileMailer{dir: dir, from: from}, nil
+}
+
+// Send writes the message to <dir>/<timestamp>-<n>.eml.
+func (m *FileMailer) Send(msg Message) error {
+    name := fmt.Sprintf("%d-%d.eml", time.Now().UnixNano(), m.seq.Add(1))
+    return os.WriteFile(filepath.Join(m.dir, name), formatMessage(m.from, msg), 0o640)
+}
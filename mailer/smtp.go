@@ -0,0 +1,49 @@
+// mailer/smtp.go
+package mailer
+
+import (
+    "fmt"
+    "net"
+    "net/smtp"
+    "strings"
+    "time"
+)
+
+// SMTPMailer delivers messages through an SMTP server using PLAIN auth.
+type SMTPMailer struct {
+    addr string
+    host string
+    auth smtp.Auth
+    from string
+}
+
+// NewSMTPMailer creates an SMTP mailer. Leave user empty for servers without auth.
+func NewSMTPMailer(host, port, user, password, from string) *SMTPMailer {
+    m := &SMTPMailer{
+        addr: net.JoinHostPort(host, port),
+        host: host,
+        from: from,
+    }
+    if user != "" {
+        m.auth = smtp.PlainAuth("", user, password, host)
+    }
+    return m
+}
+
+// Send delivers the message.
+func (m *SMTPMailer) Send(msg Message) error {
+    return smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, formatMessage(m.from, msg))
+}
+
+// formatMessage renders a minimal RFC 5322 message.
+func formatMessage(from string, msg Message) []byte {
+    var b strings.Builder
+    fmt.Fprintf(&b, "From: %s\r\n", from)
+    fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+    fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+    fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+    b.WriteString("MIME-Version: 1.0\r\n")
+    b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+    b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+    return []byte(b.String())
+}
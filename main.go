@@ -2,83 +2,270 @@
 package main
 
 import (
+    "context"
+    "errors"
     "log"
+    "log/slog"
+    "net"
     "net/http"
+    "os"
+    "os/signal"
+    "strings"
+    "syscall"
+    "time"
 
     "github.com/gin-gonic/gin"
-    "github.com/golang-jwt/jwt/v4"
+    "google.golang.org/grpc"
     "gorm.io/driver/postgres"
     "gorm.io/gorm"
 
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/grpcapi"
     "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/ratelimit"
+    "github.com/bhushangupta162/bank_management/services"
+    "github.com/bhushangupta162/bank_management/stream"
+    "github.com/bhushangupta162/bank_management/tracing"
     "github.com/bhushangupta162/bank_management/handlers"
+    "github.com/bhushangupta162/bank_management/jobs"
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/mailer"
     "github.com/bhushangupta162/bank_management/utils"
 )
 
 func main() {
-    // Create a new Gin router.
-    router := gin.Default()
+    // Load settings from the environment (see config/config.go for defaults).
+    cfg := config.Load()
+
+    // Structured JSON logs; every package logs through this handler, so redaction applies everywhere.
+    logger := logging.New(cfg.Log)
+    slog.SetDefault(logger)
+
+    // OpenTelemetry tracing (TRACING_EXPORTER=none|stdout|file|otlp).
+    shutdownTracing, err := tracing.Setup(cfg.Tracing)
+    if err != nil {
+        log.Fatal("Failed to set up tracing:", err)
+    }
+
+    // Cancelled on SIGINT/SIGTERM to stop background jobs and drain the server.
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    // Create a new Gin router. Access and panic logs go through slog instead of gin's text logger.
+    router := gin.New()
+    // X-Forwarded-For is only honored from TRUSTED_PROXIES; otherwise ClientIP
+    // (rate limit keys, audit and access logs) is the connecting address.
+    if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+        log.Fatal("Invalid TRUSTED_PROXIES:", err)
+    }
 
     // Connect to PostgreSQL.
-    // Make sure this DSN matches your PostgreSQL settings (using Docker or local installation).
-    dsn := "host=postgres user=postgres password=postgres dbname=bank port=5432 sslmode=disable"
-    db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+    // The DSN is built from DB_HOST, DB_USER, DB_PASSWORD, DB_NAME and DB_PORT (or DATABASE_DSN).
+    db, err := gorm.Open(postgres.Open(cfg.DatabaseDSN), &gorm.Config{Logger: logging.NewGormLogger()})
     if err != nil {
         log.Fatal("Failed to connect to database:", err)
     }
+    if err := tracing.InstrumentDB(db); err != nil {
+        log.Fatal("Failed to instrument database:", err)
+    }
 
     // Migrate the User model.
     db.AutoMigrate(&models.User{})
     db.AutoMigrate(&models.User{}, &models.Account{})
     db.AutoMigrate(&models.User{},&models.Account{},&models.Transaction{},)
     db.AutoMigrate(&models.User{},&models.Account{},&models.Transaction{},&models.Loan{},)
-    
-
-    // Define the authentication routes.
-    router.POST("/signup", handlers.SignUpHandler(db))
-    router.POST("/login", handlers.LoginHandler(db))
-
-    // Account routes
-    router.POST("/accounts", handlers.CreateAccountHandler(db))             // Create an account
-    router.GET("/accounts/:id", handlers.GetAccountHandler(db))
-    router.POST("/accounts/:id/deposit", handlers.DepositHandler(db))
-    router.POST("/accounts/:id/withdraw", handlers.WithdrawHandler(db))
-    router.POST("/accounts/transfer", handlers.TransferHandler(db))
-    router.GET("/accounts/:id/transactions", handlers.GetTransactionsHandler(db))
-
-    // Loan endpoints
-    router.POST("/loans/apply", handlers.ApplyLoanHandler(db))
-    router.PATCH("/loans/:id/status", handlers.UpdateLoanStatusHandler(db))   // Approve/Reject
-    router.POST("/loans/:id/repay", handlers.RepayLoanHandler(db))
-
-    // Example protected route.
-    router.GET("/protected", AuthMiddleware(), func(c *gin.Context) {
-        c.JSON(http.StatusOK, gin.H{"message": "You are authorized!"})
+    db.AutoMigrate(&models.RecoveryCode{}, &models.UserToken{})
+    db.AutoMigrate(&models.AccountStatusChange{})
+    db.AutoMigrate(&models.TransactionLimit{})
+    db.AutoMigrate(&models.ScheduledTransfer{}, &models.ScheduledTransferRun{})
+    db.AutoMigrate(&models.OutboxEvent{}, &models.WebhookSubscription{}, &models.WebhookDelivery{})
+    db.AutoMigrate(&models.AuditEntry{})
+    db.AutoMigrate(&models.RateLimitBucket{})
+    db.AutoMigrate(&models.PendingAction{})
+    db.AutoMigrate(&models.LoanProduct{})
+    db.AutoMigrate(&models.LoanInstallment{})
+    db.AutoMigrate(&models.LoanCollection{})
+    db.AutoMigrate(&models.Hold{})
+
+    // The audit log is append-only at the database level too.
+    if err := audit.EnsureImmutable(db); err != nil {
+        log.Fatal("Failed to protect audit log:", err)
+    }
+
+    // Give accounts created before account numbers existed a number (and IBAN if enabled).
+    if err := handlers.BackfillAccountNumbers(db, cfg.AccountNumbers); err != nil {
+        log.Fatal("Failed to backfill account numbers:", err)
+    }
+
+    // Loan applications need a product; give a fresh database one to start with.
+    if err := services.EnsureDefaultLoanProduct(db, cfg.Underwriting); err != nil {
+        log.Fatal("Failed to seed loan products:", err)
+    }
+
+    // Promote the bootstrap admin so staff-only routes can be used on a fresh database.
+    if cfg.BootstrapAdminEmail != "" {
+        db.Model(&models.User{}).Where("email = ?", cfg.BootstrapAdminEmail).Update("role", models.RoleAdmin)
+    }
+
+    // Outgoing email (verification and password reset links).
+    mail, err := mailer.New(cfg.Mail)
+    if err != nil {
+        log.Fatal("Failed to configure mailer:", err)
+    }
+
+    // Rate limit buckets live in memory unless RATE_LIMIT_STORE=postgres shares them between instances.
+    var limitStore ratelimit.Store = ratelimit.NewMemoryStore()
+    if cfg.RateLimit.Store == "postgres" {
+        pgStore := ratelimit.NewPostgresStore(db)
+        limitStore = pgStore
+        go jobs.Every(ctx, "rate-limit-prune", time.Hour, func(ctx context.Context) error {
+            return pgStore.Prune(ctx, 24*time.Hour)
+        })
+    }
+    limiter := ratelimit.New(limitStore, cfg.RateLimit.Enabled)
+
+    // Live account streams: the relay publishes committed transactions from the outbox.
+    broker := stream.NewMemoryBroker(cfg.Stream.Buffer)
+    go jobs.Every(ctx, "stream-relay", cfg.Stream.PollInterval, stream.NewRelay(db, broker).Run)
+
+    registerRoutes(router, db, mail, cfg, logger, limiter, broker)
+
+    // Connection pool stats for /metrics.
+    if sqlDB, err := db.DB(); err == nil {
+        if err := metrics.RegisterDB(sqlDB, "bank"); err != nil {
+            log.Fatal("Failed to register DB metrics:", err)
+        }
+    }
+
+    // Background worker that executes due scheduled transfers.
+    if cfg.Scheduler.Enabled {
+        go jobs.Every(ctx, "scheduled-transfers", cfg.Scheduler.Interval, func(ctx context.Context) error {
+            _, err := services.RunDueScheduledTransfers(db, cfg.Limits, cfg.Approval, cfg.Scheduler, time.Now())
+            return err
+        })
+    }
+
+    // Background worker that expires maker-checker proposals nobody decided in time.
+    go jobs.Every(ctx, "pending-action-expiry", cfg.Approval.ExpiryInterval, func(ctx context.Context) error {
+        _, err := services.ExpirePendingActions(db.WithContext(ctx), time.Now())
+        return err
+    })
+
+    // Background worker that charges late fees and moves overdue loans to delinquent or defaulted.
+    go jobs.Every(ctx, "loan-delinquency", cfg.Loan.DelinquencyInterval, func(ctx context.Context) error {
+        _, err := services.RunLoanDelinquency(db.WithContext(ctx), cfg.Loan, time.Now())
+        return err
+    })
+
+    // Background worker that collects due loan installments from repayment accounts.
+    go jobs.Every(ctx, "loan-collection", cfg.Loan.CollectionInterval, func(ctx context.Context) error {
+        _, err := services.RunLoanCollections(db.WithContext(ctx), cfg.Loan, time.Now())
+        return err
+    })
+
+    // Background worker that releases holds nobody captured before they expired.
+    go jobs.Every(ctx, "hold-expiry", cfg.Holds.ExpiryInterval, func(ctx context.Context) error {
+        _, err := services.ExpireHolds(db.WithContext(ctx), time.Now())
+        return err
     })
 
-    // Start the server on port 8080.
-    router.Run(":8080")
+    // Background worker that charges daily interest on overdrawn accounts.
+    go jobs.Every(ctx, "overdraft-interest", cfg.Overdraft.InterestInterval, func(ctx context.Context) error {
+        _, err := services.RunOverdraftInterest(db.WithContext(ctx), time.Now())
+        return err
+    })
+
+    // Background worker that turns outbox events into signed webhook deliveries.
+    if cfg.Webhooks.Enabled {
+        sender := services.NewWebhookSender(db, cfg.Webhooks)
+        go jobs.Every(ctx, "webhooks", cfg.Webhooks.Interval, sender.RunWebhooks)
+    }
+
+    // gRPC API for internal services, on its own port (GRPC_ADDR).
+    var grpcSrv *grpc.Server
+    if cfg.GRPC.Enabled {
+        lis, err := net.Listen("tcp", cfg.GRPC.Addr)
+        if err != nil {
+            log.Fatal("Failed to listen for gRPC:", err)
+        }
+        grpcSrv = grpcapi.NewServer(db, cfg, logger)
+        go func() {
+            if err := grpcSrv.Serve(lis); err != nil {
+                log.Fatal("gRPC server failed:", err)
+            }
+        }()
+    }
+
+    // Start the server on port 8080 and drain it on shutdown.
+    srv := &http.Server{Addr: ":8080", Handler: router}
+    go func() {
+        if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            log.Fatal("Server failed:", err)
+        }
+    }()
+
+    <-ctx.Done()
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+    if err := srv.Shutdown(shutdownCtx); err != nil {
+        slog.Error("server shutdown", "error", err)
+    }
+    if grpcSrv != nil {
+        grpcapi.Shutdown(shutdownCtx, grpcSrv)
+    }
+    if err := shutdownTracing(shutdownCtx); err != nil {
+        slog.Error("tracing shutdown", "error", err)
+    }
 }
 
 // AuthMiddleware verifies JWT tokens for protected endpoints.
 func AuthMiddleware() gin.HandlerFunc {
     return func(c *gin.Context) {
-        tokenString := c.GetHeader("Authorization")
+        tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
         if tokenString == "" {
             c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing Authorization header"})
             return
         }
 
-        // Parse and validate the token.
-        token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-            return utils.JwtSecret, nil
-        })
-        if err != nil || !token.Valid {
+        // Parse and validate the token. MFA challenge tokens are rejected here.
+        userID, err := utils.ParseToken(tokenString, utils.TokenTypeAccess)
+        if err != nil {
             c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
             return
         }
 
         // Token is valid, continue.
+        c.Set("user_id", userID)
+        logging.AddAttrs(c, "user_id", userID)
         c.Next()
     }
 }
+
+// RequireRole allows the request only if the authenticated user has one of the given roles.
+// It must run after AuthMiddleware.
+func RequireRole(db *gorm.DB, roles ...string) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        db := db.WithContext(c.Request.Context())
+        userID, ok := c.Get("user_id")
+        if !ok {
+            c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+            return
+        }
+
+        var user models.User
+        if err := db.Select("id", "role").First(&user, userID).Error; err != nil {
+            c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+            return
+        }
+        for _, role := range roles {
+            if user.Role == role {
+                c.Set("user_role", user.Role)
+                c.Next()
+                return
+            }
+        }
+        c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+    }
+}
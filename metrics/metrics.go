@@ -0,0 +1,176 @@
+// metrics/metrics.go
+package metrics
+
+import (
+    "database/sql"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/collectors"
+    "github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Labels are limited to small, fixed sets (route patterns, status codes,
+// transaction types, currencies, loan statuses). Never use IDs, account
+// numbers or raw paths as label values.
+
+var (
+    requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+        Name:    "http_request_duration_seconds",
+        Help:    "HTTP request latency by route pattern.",
+        Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+    }, []string{"method", "route"})
+
+    requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "http_requests_total",
+        Help: "HTTP requests by route pattern and status code.",
+    }, []string{"method", "route", "code"})
+
+    requestErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "http_request_errors_total",
+        Help: "HTTP responses with a 4xx or 5xx status, by status code.",
+    }, []string{"code"})
+
+    moneyVolume = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "bank_money_volume_total",
+        Help: "Amount moved by deposits, withdrawals and transfers, in units of the currency.",
+    }, []string{"type", "currency"})
+
+    moneyOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "bank_money_operations_total",
+        Help: "Number of completed deposits, withdrawals and transfers.",
+    }, []string{"type", "currency"})
+
+    loanApplications = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "bank_loan_applications_total",
+        Help: "Loan applications by the status they moved to (pending on application).",
+    }, []string{"status"})
+
+    underwritingDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "bank_loan_underwriting_decisions_total",
+        Help: "Underwriting engine outcomes (approve, refer, decline).",
+    }, []string{"decision"})
+
+    failedLogins = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "bank_failed_logins_total",
+        Help: "Failed login attempts by reason.",
+    }, []string{"reason"})
+
+    deprecatedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "http_deprecated_requests_total",
+        Help: "Requests to deprecated legacy routes, to see who still needs to migrate before the sunset.",
+    }, []string{"method", "route"})
+
+    streamSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+        Name: "bank_stream_subscribers",
+        Help: "Open account streams (GET /accounts/:id/stream).",
+    })
+
+    streamDropped = promauto.NewCounter(prometheus.CounterOpts{
+        Name: "bank_stream_dropped_total",
+        Help: "Stream subscribers disconnected because they fell behind.",
+    })
+)
+
+// Money movement types used as the "type" label.
+const (
+    TypeDeposit    = "deposit"
+    TypeWithdrawal = "withdrawal"
+    TypeTransfer   = "transfer"
+    TypeReversal   = "reversal"
+    TypeAdjustment = "adjustment"
+    TypeLoanPayout = "loan-payout"
+    TypeLoanRepay  = "loan-repayment"
+    TypeOverdraft  = "overdraft" // Overdraft fees and interest
+    TypeHold       = "hold-capture"
+)
+
+// OtherCurrency is the "currency" label for codes outside isoCurrencies.
+const OtherCurrency = "other"
+
+// isoCurrencies are the active ISO 4217 codes. Accounts only need a code-shaped
+// currency, so anything else is folded into OtherCurrency to keep the label set
+// bounded.
+var isoCurrencies = map[string]struct{}{
+    "AED": {}, "AFN": {}, "ALL": {}, "AMD": {}, "ANG": {}, "AOA": {}, "ARS": {}, "AUD": {},
+    "AWG": {}, "AZN": {}, "BAM": {}, "BBD": {}, "BDT": {}, "BGN": {}, "BHD": {}, "BIF": {},
+    "BMD": {}, "BND": {}, "BOB": {}, "BRL": {}, "BSD": {}, "BTN": {}, "BWP": {}, "BYN": {},
+    "BZD": {}, "CAD": {}, "CDF": {}, "CHF": {}, "CLP": {}, "CNY": {}, "COP": {}, "CRC": {},
+    "CUP": {}, "CVE": {}, "CZK": {}, "DJF": {}, "DKK": {}, "DOP": {}, "DZD": {}, "EGP": {},
+    "ERN": {}, "ETB": {}, "EUR": {}, "FJD": {}, "FKP": {}, "GBP": {}, "GEL": {}, "GHS": {},
+    "GIP": {}, "GMD": {}, "GNF": {}, "GTQ": {}, "GYD": {}, "HKD": {}, "HNL": {}, "HTG": {},
+    "HUF": {}, "IDR": {}, "ILS": {}, "INR": {}, "IQD": {}, "IRR": {}, "ISK": {}, "JMD": {},
+    "JOD": {}, "JPY": {}, "KES": {}, "KGS": {}, "KHR": {}, "KMF": {}, "KPW": {}, "KRW": {},
+    "KWD": {}, "KYD": {}, "KZT": {}, "LAK": {}, "LBP": {}, "LKR": {}, "LRD": {}, "LSL": {},
+    "LYD": {}, "MAD": {}, "MDL": {}, "MGA": {}, "MKD": {}, "MMK": {}, "MNT": {}, "MOP": {},
+    "MRU": {}, "MUR": {}, "MVR": {}, "MWK": {}, "MXN": {}, "MYR": {}, "MZN": {}, "NAD": {},
+    "NGN": {}, "NIO": {}, "NOK": {}, "NPR": {}, "NZD": {}, "OMR": {}, "PAB": {}, "PEN": {},
+    "PGK": {}, "PHP": {}, "PKR": {}, "PLN": {}, "PYG": {}, "QAR": {}, "RON": {}, "RSD": {},
+    "RUB": {}, "RWF": {}, "SAR": {}, "SBD": {}, "SCR": {}, "SDG": {}, "SEK": {}, "SGD": {},
+    "SHP": {}, "SLE": {}, "SOS": {}, "SRD": {}, "SSP": {}, "STN": {}, "SVC": {}, "SYP": {},
+    "SZL": {}, "THB": {}, "TJS": {}, "TMT": {}, "TND": {}, "TOP": {}, "TRY": {}, "TTD": {},
+    "TWD": {}, "TZS": {}, "UAH": {}, "UGX": {}, "USD": {}, "UYU": {}, "UZS": {}, "VES": {},
+    "VND": {}, "VUV": {}, "WST": {}, "XAF": {}, "XCD": {}, "XOF": {}, "XPF": {}, "YER": {},
+    "ZAR": {}, "ZMW": {}, "ZWG": {},
+}
+
+// currencyLabel maps a currency to its "currency" label value.
+func currencyLabel(currency string) string {
+    if _, ok := isoCurrencies[currency]; ok {
+        return currency
+    }
+    return OtherCurrency
+}
+
+// Failed login reasons used as the "reason" label.
+const (
+    LoginUnknownUser = "unknown_user"
+    LoginBadPassword = "bad_password"
+    LoginBadMFACode  = "bad_mfa_code"
+)
+
+// RegisterDB exposes the connection pool stats (open, in use, idle, waits).
+func RegisterDB(db *sql.DB, name string) error {
+    return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// MoneyMoved records a committed deposit, withdrawal or transfer.
+func MoneyMoved(kind, currency string, amount float64) {
+    currency = currencyLabel(currency)
+    moneyVolume.WithLabelValues(kind, currency).Add(amount)
+    moneyOperations.WithLabelValues(kind, currency).Inc()
+}
+
+// LoanStatus records a loan application entering a status.
+func LoanStatus(status string) {
+    loanApplications.WithLabelValues(status).Inc()
+}
+
+// UnderwritingDecision records the engine's outcome for a loan application.
+func UnderwritingDecision(decision string) {
+    underwritingDecisions.WithLabelValues(decision).Inc()
+}
+
+// FailedLogin records a rejected login attempt.
+func FailedLogin(reason string) {
+    failedLogins.WithLabelValues(reason).Inc()
+}
+
+// DeprecatedRequest records a call to a deprecated route.
+func DeprecatedRequest(method, route string) {
+    deprecatedRequests.WithLabelValues(method, route).Inc()
+}
+
+// StreamOpened records a client connecting to an account stream.
+func StreamOpened() {
+    streamSubscribers.Inc()
+}
+
+// StreamClosed records a client leaving an account stream.
+func StreamClosed() {
+    streamSubscribers.Dec()
+}
+
+// StreamDropped records a subscriber that was disconnected for falling behind.
+func StreamDropped() {
+    streamDropped.Inc()
+}
@@ -0,0 +1,52 @@
+// metrics/middleware.go
+package metrics
+
+import (
+    "crypto/subtle"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Middleware records latency and status codes per route pattern. Requests
+// that match no route share the "unmatched" label so scanners cannot blow
+// up the number of series.
+func Middleware() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        start := time.Now()
+        c.Next()
+
+        route := c.FullPath()
+        if route == "" {
+            route = "unmatched"
+        }
+        status := c.Writer.Status()
+        code := strconv.Itoa(status)
+
+        requestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+        requestsTotal.WithLabelValues(c.Request.Method, route, code).Inc()
+        if status >= http.StatusBadRequest {
+            requestErrors.WithLabelValues(code).Inc()
+        }
+    }
+}
+
+// Handler serves the Prometheus exposition format. When token is set, the
+// scraper must send "Authorization: Bearer <token>".
+func Handler(token string) gin.HandlerFunc {
+    h := promhttp.Handler()
+    return func(c *gin.Context) {
+        if token != "" {
+            got := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+            if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+                c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid metrics token"})
+                return
+            }
+        }
+        h.ServeHTTP(c.Writer, c.Request)
+    }
+}
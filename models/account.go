@@ -2,18 +2,119 @@
 package models
 
 import (
+    "encoding/json"
     "time"
 
     "gorm.io/gorm"
 )
 
+// Account lifecycle states.
+const (
+    AccountStatusActive  = "active"
+    AccountStatusFrozen  = "frozen"  // Blocked for review; no debits
+    AccountStatusDormant = "dormant" // No customer activity for a long time
+    AccountStatusClosed  = "closed"  // Terminal; no debits or credits
+)
+
+// accountStatusTransitions lists the allowed status changes.
+var accountStatusTransitions = map[string][]string{
+    AccountStatusActive:  {AccountStatusFrozen, AccountStatusDormant, AccountStatusClosed},
+    AccountStatusFrozen:  {AccountStatusActive, AccountStatusClosed},
+    AccountStatusDormant: {AccountStatusActive, AccountStatusFrozen, AccountStatusClosed},
+    AccountStatusClosed:  {},
+}
+
 type Account struct {
     ID        uint           `gorm:"primaryKey" json:"id"`
     CreatedAt time.Time      `json:"created_at"`
     UpdatedAt time.Time      `json:"updated_at"`
     DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
     
-    UserID  uint    `json:"user_id"`                      // Foreign key to User
-    Balance float64 `json:"balance" gorm:"not null;default:0"`
-    // Add more fields like AccountType if needed
+    UserID        uint       `json:"user_id"`                      // Foreign key to User
+    AccountNumber *string    `json:"account_number" gorm:"uniqueIndex;size:34"` // Check-digit validated, see utils.GenerateAccountNumber
+    IBAN          *string    `json:"iban,omitempty" gorm:"uniqueIndex;size:34"` // Only when IBAN_ENABLED is set
+    Product       string     `json:"product" gorm:"not null;default:standard"` // Account product, drives limits
+    Currency      string     `json:"currency" gorm:"size:3;not null;default:EUR"` // ISO 4217 code
+    Balance       float64    `json:"balance" gorm:"not null;default:0"`
+    Status        string     `json:"status" gorm:"not null;default:active;index"` // active, frozen, dormant, closed
+    ClosedAt      *time.Time `json:"closed_at,omitempty"`
+
+    OverdraftLimit     float64    `json:"overdraft_limit" gorm:"not null;default:0"` // Agreed overdraft: how far below zero the balance may go
+    OverdraftRate      float64    `json:"overdraft_rate" gorm:"not null;default:0"`  // Annual interest in percent on a negative balance, charged daily
+    OverdraftFee       float64    `json:"overdraft_fee" gorm:"not null;default:0"`   // Charged when a debit takes the balance below zero
+    OverdraftChargedAt *time.Time `json:"overdraft_charged_at,omitempty"`            // Last time overdraft interest was charged
+
+    HeldAmount float64 `json:"held_amount" gorm:"not null;default:0"` // Sum of the active holds, see Hold
+}
+
+// MarshalJSON adds the ledger and available balances to the account's JSON.
+// The ledger balance is Balance, the sum of the posted transactions.
+func (a Account) MarshalJSON() ([]byte, error) {
+    type account Account // Drops the method, so this does not recurse
+    return json.Marshal(struct {
+        account
+        LedgerBalance    float64 `json:"ledger_balance"`
+        AvailableBalance float64 `json:"available_balance"`
+    }{account(a), a.Balance, a.AvailableBalance()})
+}
+
+// DefaultAccountProduct is used when an account is opened without a product.
+const DefaultAccountProduct = "standard"
+
+// DefaultCurrency is used when an account is opened without a currency.
+const DefaultCurrency = "EUR"
+
+// IsValidCurrency reports whether s looks like an ISO 4217 code ("EUR", "USD").
+func IsValidCurrency(s string) bool {
+    if len(s) != 3 {
+        return false
+    }
+    for _, r := range s {
+        if r < 'A' || r > 'Z' {
+            return false
+        }
+    }
+    return true
+}
+
+// IsValidAccountStatus reports whether s is a known account status.
+func IsValidAccountStatus(s string) bool {
+    _, ok := accountStatusTransitions[s]
+    return ok
+}
+
+// CanTransitionTo reports whether the account may move to the given status.
+func (a *Account) CanTransitionTo(status string) bool {
+    for _, next := range accountStatusTransitions[a.currentStatus()] {
+        if next == status {
+            return true
+        }
+    }
+    return false
+}
+
+// CanDebit reports whether money may leave the account.
+func (a *Account) CanDebit() bool {
+    s := a.currentStatus()
+    return s != AccountStatusFrozen && s != AccountStatusClosed
+}
+
+// CanCredit reports whether money may be paid into the account.
+func (a *Account) CanCredit() bool {
+    return a.currentStatus() != AccountStatusClosed
+}
+
+// AvailableBalance is what can be spent: the balance plus the overdraft limit,
+// less what active holds reserve. Every debit is checked against it rather
+// than the balance.
+func (a *Account) AvailableBalance() float64 {
+    return a.Balance + a.OverdraftLimit - a.HeldAmount
+}
+
+// currentStatus treats rows created before the status column existed as active.
+func (a *Account) currentStatus() string {
+    if a.Status == "" {
+        return AccountStatusActive
+    }
+    return a.Status
 }
@@ -0,0 +1,35 @@
+// models/account_status.go
+package models
+
+import "time"
+
+// Reason codes accepted for account status changes.
+var AccountStatusReasonCodes = map[string]string{
+    "fraud_review":     "Suspected fraud under review",
+    "compliance_hold":  "Compliance or KYC hold",
+    "legal_order":      "Court or regulator order",
+    "inactivity":       "No customer activity",
+    "review_cleared":   "Review finished, restrictions lifted",
+    "customer_request": "Requested by the customer",
+    "reactivated":      "Customer activity resumed",
+    "deceased":         "Account holder deceased",
+}
+
+// CustomerCloseReasonCodes are the reason codes an account owner may give when
+// closing their own account. The others are for staff.
+var CustomerCloseReasonCodes = map[string]bool{
+    "customer_request": true,
+}
+
+// AccountStatusChange is an append-only history entry for account status changes.
+type AccountStatusChange struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+
+    AccountID  uint   `gorm:"index;not null" json:"account_id"`
+    FromStatus string `json:"from_status"`
+    ToStatus   string `json:"to_status"`
+    ReasonCode string `gorm:"not null" json:"reason_code"`
+    Note       string `json:"note"`
+    ActorID    uint   `json:"actor_id"` // User who made the change
+}
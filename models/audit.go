@@ -0,0 +1,26 @@
+// models/audit.go
+package models
+
+import "time"
+
+// AuditEntry is one append-only record of a state-changing request.
+// Entries form a hash chain: Hash covers the entry's fields and PrevHash, so
+// editing or deleting any entry breaks every hash after it.
+type AuditEntry struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `gorm:"index;not null" json:"created_at"`
+
+    ActorID    *uint  `gorm:"index" json:"actor_id"`  // nil for anonymous requests (signup, login)
+    Action     string `gorm:"not null" json:"action"` // e.g. "POST /accounts/:id/deposit"
+    EntityType string `gorm:"index:idx_audit_entity" json:"entity_type,omitempty"`
+    EntityID   string `gorm:"index:idx_audit_entity" json:"entity_id,omitempty"`
+    Before     string `gorm:"type:text" json:"before,omitempty"` // JSON snapshot
+    After      string `gorm:"type:text" json:"after,omitempty"`  // JSON snapshot
+    Diff       string `gorm:"type:text" json:"diff,omitempty"`   // JSON {"field": {"from": x, "to": y}}
+    StatusCode int    `json:"status_code"`
+    IP         string `json:"ip"`
+    RequestID  string `gorm:"index" json:"request_id"`
+
+    PrevHash string `gorm:"not null" json:"prev_hash"`
+    Hash     string `gorm:"uniqueIndex;not null" json:"hash"`
+}
@@ -0,0 +1,32 @@
+// models/hold.go
+package models
+
+import "time"
+
+// Hold statuses. Only active holds reduce the available balance.
+const (
+    HoldStatusActive   = "active"
+    HoldStatusCaptured = "captured" // Settled into a hold-capture transaction, fully or partly
+    HoldStatusReleased = "released"
+    HoldStatusExpired  = "expired" // Released by the expiry job
+)
+
+// Hold reserves funds on an account before settlement, like a card
+// authorization. The account's Balance is untouched until the hold is
+// captured; its HeldAmount is the sum of its active holds.
+type Hold struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+
+    AccountID      uint       `gorm:"index;not null" json:"account_id"`
+    Amount         float64    `gorm:"not null" json:"amount"`
+    Description    string     `json:"description"`
+    Reference      string     `gorm:"index" json:"reference,omitempty"` // Caller's own ID, e.g. a card authorization code
+    Status         string     `gorm:"not null;default:active;index" json:"status"`
+    ExpiresAt      time.Time  `gorm:"index" json:"expires_at"`
+    ClosedAt       *time.Time `json:"closed_at,omitempty"`      // When it was captured, released or expired
+    CapturedAmount float64    `json:"captured_amount"`          // May be less than Amount; the rest is released
+    TransactionID  *uint      `json:"transaction_id,omitempty"` // The hold-capture debit
+    CreatedBy      uint       `json:"created_by"`
+}
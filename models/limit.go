@@ -0,0 +1,30 @@
+// models/limit.go
+package models
+
+import "time"
+
+// Limit scopes.
+const (
+    LimitScopeProduct = "product" // Applies to each account of an account product
+    LimitScopeUser    = "user"    // Applies to the total of all of a user's accounts
+)
+
+// Outflow transaction types counted against limits.
+var OutflowTransactionTypes = []string{TransactionWithdrawal, TransactionTransferOut, TransactionHoldCapture}
+
+// TransactionLimit caps money leaving accounts. A zero value means "no limit".
+// Product limits apply per account; user limits apply across all of the user's accounts.
+type TransactionLimit struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+
+    Scope   string `gorm:"not null;uniqueIndex:idx_limit_target" json:"scope"` // "product" or "user"
+    Product string `gorm:"not null;default:'';uniqueIndex:idx_limit_target" json:"product,omitempty"`
+    UserID  uint   `gorm:"not null;default:0;uniqueIndex:idx_limit_target" json:"user_id,omitempty"`
+
+    SingleMax           float64 `json:"single_max"`            // Largest single withdrawal or transfer
+    DailyMax            float64 `json:"daily_max"`             // Total outflow per calendar day (UTC)
+    MonthlyMax          float64 `json:"monthly_max"`           // Total outflow per calendar month (UTC)
+    HourlyTransferCount int     `json:"hourly_transfer_count"` // Outgoing transfers in any rolling hour
+}
@@ -2,11 +2,71 @@
 package models
 
 import (
+    "strings"
     "time"
 
     "gorm.io/gorm"
 )
 
+// Loan lifecycle states.
+const (
+    LoanStatusPending    = "pending"     // Applied for, waiting for a decision
+    LoanStatusApproved   = "approved"    // Approved, not yet paid out
+    LoanStatusRejected   = "rejected"    // Terminal
+    LoanStatusCancelled  = "cancelled"   // Withdrawn before payout; terminal
+    LoanStatusActive     = "active"      // Disbursed and repaid on schedule
+    LoanStatusDelinquent = "delinquent"  // An installment is past due
+    LoanStatusDefaulted  = "defaulted"   // Past due for LOAN_DEFAULT_AFTER_DAYS, or declared by staff
+    LoanStatusWrittenOff = "written_off" // Recognized as a loss; terminal
+    LoanStatusClosed     = "closed"      // Fully repaid; terminal
+)
+
+// loanStatusTransitions lists the allowed status changes.
+var loanStatusTransitions = map[string][]string{
+    LoanStatusPending:    {LoanStatusApproved, LoanStatusRejected, LoanStatusCancelled},
+    LoanStatusApproved:   {LoanStatusActive, LoanStatusCancelled},
+    LoanStatusActive:     {LoanStatusDelinquent, LoanStatusDefaulted, LoanStatusClosed},
+    LoanStatusDelinquent: {LoanStatusActive, LoanStatusDefaulted, LoanStatusClosed},
+    LoanStatusDefaulted:  {LoanStatusWrittenOff, LoanStatusClosed},
+    LoanStatusRejected:   {},
+    LoanStatusCancelled:  {},
+    LoanStatusWrittenOff: {},
+    LoanStatusClosed:     {},
+}
+
+// ManualLoanStatuses are the statuses staff may move a loan to through
+// PATCH /loans/:id/status. The others follow from payout, repayments and the
+// delinquency job.
+var ManualLoanStatuses = []string{LoanStatusApproved, LoanStatusRejected, LoanStatusCancelled, LoanStatusDefaulted, LoanStatusWrittenOff}
+
+// OpenLoanStatuses are the statuses in which a loan still has a balance the
+// borrower owes or will owe.
+var OpenLoanStatuses = []string{LoanStatusPending, LoanStatusApproved, LoanStatusActive, LoanStatusDelinquent, LoanStatusDefaulted}
+
+// Days-past-due buckets.
+const (
+    DelinquencyCurrent = "current"
+    Delinquency1to29   = "1-29"
+    Delinquency30to59  = "30-59"
+    Delinquency60to89  = "60-89"
+    Delinquency90Plus  = "90+"
+)
+
+// DelinquencyBucket returns the bucket for a number of days past due.
+func DelinquencyBucket(daysPastDue int) string {
+    switch {
+    case daysPastDue <= 0:
+        return DelinquencyCurrent
+    case daysPastDue < 30:
+        return Delinquency1to29
+    case daysPastDue < 60:
+        return Delinquency30to59
+    case daysPastDue < 90:
+        return Delinquency60to89
+    }
+    return Delinquency90Plus
+}
+
 // Loan represents a credit/loan that a user can apply for.
 type Loan struct {
     ID           uint           `gorm:"primaryKey" json:"id"`
@@ -18,9 +78,76 @@ type Loan struct {
     Principal    float64 `json:"principal"`          // Original amount
     InterestRate float64 `json:"interest_rate"`      // Annual interest rate (e.g., 5.0 = 5%)
     TermMonths   int     `json:"term_months"`        // For example, 12, 24, 36 months, etc.
-    Status       string  `json:"status"`             // e.g. "pending", "approved", "rejected", "active", "closed"
+    Status       string  `json:"status"`             // See the LoanStatus* constants
 
     // You might track extra fields:
     OutstandingBalance float64 `json:"outstanding_balance"` // How much is left to repay
+
+    DecidedByID *uint      `json:"decided_by_id"` // Staff member who approved or rejected the loan; empty for automatic decisions
+    DecidedAt   *time.Time `json:"decided_at"`
     // You can also add fields like monthlyPayment, nextPaymentDue, etc. as needed
+
+    // Underwriting outcome, stored when the application is made
+    UnderwritingDecision string `json:"underwriting_decision,omitempty"`           // See the Underwriting* constants
+    CreditScore          int    `json:"credit_score,omitempty"`                    // 300-850, higher is better
+    ReasonCodes          string `json:"reason_codes,omitempty"`                    // Comma separated, see services.Reason*
+    CreditProfile        string `gorm:"type:text" json:"credit_profile,omitempty"` // JSON inputs the decision was based on
+
+    // Product terms, copied from the loan product when the application is made
+    ProductID      *uint   `gorm:"index" json:"product_id,omitempty"`
+    ProductCode    string  `json:"product_code,omitempty"`
+    OriginationFee float64 `json:"origination_fee"` // Amount, from the product's percentage
+    LoanFeeTerms   `gorm:"embedded"`
+
+    // Servicing, once the loan is paid out
+    DisbursedAt           *time.Time `json:"disbursed_at,omitempty"`
+    DisbursementAccountID *uint      `json:"disbursement_account_id,omitempty"` // Account the principal was paid into
+    InstallmentAmount     float64    `json:"installment_amount,omitempty"`      // Scheduled monthly payment
+    DaysPastDue           int        `json:"days_past_due"`                     // Age of the oldest unpaid installment past its due date
+    DelinquencyBucket     string     `json:"delinquency_bucket,omitempty"`      // See the Delinquency* constants
+    FeesOutstanding       float64    `json:"fees_outstanding"`                  // Late fees charged and not yet paid
+
+    // Direct debit of due installments
+    RepaymentAccountID *uint      `gorm:"index" json:"repayment_account_id,omitempty"` // Borrower's account the collection job debits
+    LastCollectionAt   *time.Time `json:"last_collection_at,omitempty"`                 // Collection is tried at most once a day
+}
+
+// IsValidLoanStatus reports whether s is a known loan status.
+func IsValidLoanStatus(s string) bool {
+    _, ok := loanStatusTransitions[s]
+    return ok
+}
+
+// CanTransitionTo reports whether the loan may move to the given status.
+func (l *Loan) CanTransitionTo(status string) bool {
+    for _, next := range loanStatusTransitions[l.Status] {
+        if next == status {
+            return true
+        }
+    }
+    return false
+}
+
+// IsRepayable reports whether the loan has been paid out and is still owed.
+func (l *Loan) IsRepayable() bool {
+    switch l.Status {
+    case LoanStatusActive, LoanStatusDelinquent, LoanStatusDefaulted:
+        return true
+    }
+    return false
+}
+
+// Underwriting decisions.
+const (
+    UnderwritingApprove = "approve" // Approved automatically
+    UnderwritingRefer   = "refer"   // Left pending for staff to decide
+    UnderwritingDecline = "decline" // Rejected automatically
+)
+
+// Reasons returns the reason codes as a list.
+func (l *Loan) Reasons() []string {
+    if l.ReasonCodes == "" {
+        return nil
+    }
+    return strings.Split(l.ReasonCodes, ",")
 }
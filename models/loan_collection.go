@@ -0,0 +1,18 @@
+// models/loan_collection.go
+package models
+
+import "time"
+
+// LoanCollection records every attempt to direct debit a loan's due
+// installments from its repayment account, including why it failed.
+type LoanCollection struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+
+    LoanID        uint    `gorm:"index;not null" json:"loan_id"`
+    AccountID     uint    `gorm:"not null" json:"account_id"`
+    Due           float64 `json:"due"`       // Owed on the loan's due installments when the attempt ran
+    Collected     float64 `json:"collected"` // Less than Due for a partial collection, 0 when it failed
+    Error         string  `json:"error,omitempty"`
+    TransactionID *uint   `json:"transaction_id,omitempty"` // The loan-repayment debit
+}
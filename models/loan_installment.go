@@ -0,0 +1,47 @@
+// models/loan_installment.go
+package models
+
+import "time"
+
+// Installment statuses.
+const (
+    InstallmentDue    = "due" // Not fully paid; overdue once DueDate has passed
+    InstallmentPaid   = "paid"
+    InstallmentWaived = "waived" // Dropped when the loan was written off
+)
+
+// LoanInstallment is one monthly payment of a loan's repayment schedule, which
+// is built when the loan is paid out.
+type LoanInstallment struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+
+    LoanID    uint      `gorm:"not null;uniqueIndex:idx_loan_installment" json:"loan_id"`
+    Number    int       `gorm:"not null;uniqueIndex:idx_loan_installment" json:"number"` // 1-based
+    DueDate   time.Time `gorm:"index;not null" json:"due_date"`
+    Principal float64   `json:"principal"` // Principal part of the scheduled payment
+    Interest  float64   `json:"interest"`  // Interest part of the scheduled payment
+
+    PaidPrincipal float64    `json:"paid_principal"`
+    PaidInterest  float64    `json:"paid_interest"`
+    LateFee       float64    `json:"late_fee"` // Charged once the installment is past its grace period
+    PaidLateFee   float64    `json:"paid_late_fee"`
+    Status        string     `gorm:"index;not null" json:"status"`
+    PaidAt        *time.Time `json:"paid_at,omitempty"`
+}
+
+// Amount is the scheduled payment: principal plus interest.
+func (i *LoanInstallment) Amount() float64 {
+    return i.Principal + i.Interest
+}
+
+// Remaining is what is still owed on the installment, late fee included.
+func (i *LoanInstallment) Remaining() float64 {
+    return i.Principal - i.PaidPrincipal + i.Interest - i.PaidInterest + i.LateFee - i.PaidLateFee
+}
+
+// IsOverdue reports whether the installment is unpaid after its due date.
+func (i *LoanInstallment) IsOverdue(now time.Time) bool {
+    return i.Status == InstallmentDue && now.After(i.DueDate)
+}
@@ -0,0 +1,62 @@
+// models/loan_product.go
+package models
+
+import (
+    "strconv"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// LoanFeeTerms are a loan product's fee rules. They are copied onto every loan
+// applied for under the product, so later product changes never reach it.
+type LoanFeeTerms struct {
+    OriginationFeePercent    float64 `json:"origination_fee_percent"`    // Of the principal, charged when the loan is paid out
+    PrepaymentPenaltyPercent float64 `json:"prepayment_penalty_percent"` // Of principal repaid ahead of schedule
+    LateFeeFlat              float64 `json:"late_fee_flat"`              // Per missed installment
+    LateFeePercent           float64 `json:"late_fee_percent"`           // Of the missed installment, on top of the flat fee
+    LateFeeGraceDays         int     `json:"late_fee_grace_days"`        // Days after the due date before a late fee is charged
+}
+
+// LoanProduct is an entry of the loan catalog. Applications must name one and
+// are validated against its principal range, terms and rate band.
+type LoanProduct struct {
+    ID        uint           `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time      `json:"created_at"`
+    UpdatedAt time.Time      `json:"updated_at"`
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+    Code         string  `gorm:"uniqueIndex;not null" json:"code"` // e.g. "personal", "car"
+    Name         string  `gorm:"not null" json:"name"`
+    Description  string  `json:"description,omitempty"`
+    MinPrincipal float64 `json:"min_principal"`
+    MaxPrincipal float64 `json:"max_principal"`
+    Terms        string  `gorm:"not null" json:"terms"`  // Allowed terms in months, comma separated, e.g. "12,24,36"
+    MinRate      float64 `json:"min_rate"`               // Annual rate band in percent; the priced rate is kept inside it
+    MaxRate      float64 `json:"max_rate"`               // Equal to MinRate for a fixed-rate product
+    Active       bool    `gorm:"not null" json:"active"` // Inactive products take no new applications
+
+    LoanFeeTerms `gorm:"embedded"`
+}
+
+// TermMonths returns the allowed terms. Entries that are not numbers are skipped.
+func (p *LoanProduct) TermMonths() []int {
+    var terms []int
+    for _, t := range strings.Split(p.Terms, ",") {
+        if n, err := strconv.Atoi(strings.TrimSpace(t)); err == nil {
+            terms = append(terms, n)
+        }
+    }
+    return terms
+}
+
+// AllowsTerm reports whether the product can be taken over the given term.
+func (p *LoanProduct) AllowsTerm(months int) bool {
+    for _, t := range p.TermMonths() {
+        if t == months {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,21 @@
+// models/mfa.go
+package models
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// RecoveryCode is a single-use backup code for users who lose their
+// authenticator. Only the SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+    ID        uint           `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time      `json:"created_at"`
+    UpdatedAt time.Time      `json:"updated_at"`
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+    UserID   uint       `gorm:"index;not null" json:"user_id"`
+    CodeHash string     `gorm:"uniqueIndex;not null" json:"-"`
+    UsedAt   *time.Time `json:"used_at"` // nil while the code is still usable
+}
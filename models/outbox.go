@@ -0,0 +1,70 @@
+// models/outbox.go
+package models
+
+import (
+    "encoding/json"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// Event types written to the outbox.
+const (
+    EventTransactionCreated = "transaction.created"
+    EventLoanStatusChanged  = "loan.status_changed"
+    EventLoanLateFeeCharged = "loan.late_fee_charged"
+    EventAccountFrozen      = "account.frozen"
+    EventAccountDormant     = "account.dormant"
+    EventAccountClosed      = "account.closed"
+    EventAccountReactivated = "account.reactivated"
+    EventHoldCreated        = "hold.created"
+    EventHoldCaptured       = "hold.captured"
+    EventHoldReleased       = "hold.released" // Also when it expires; the hold's status tells them apart
+)
+
+// OutboxEvent is a domain event written in the same DB transaction as the
+// change it describes (transactional outbox). A dispatcher later turns each
+// event into webhook deliveries, so an event can never be lost or sent for a
+// change that was rolled back.
+type OutboxEvent struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+    EventType     string     `gorm:"index;not null" json:"type"`
+    AggregateType string     `gorm:"not null" json:"aggregate_type"` // "transaction", "loan", "account"
+    AggregateID   uint       `gorm:"not null" json:"aggregate_id"`
+    Payload       string     `gorm:"type:text;not null" json:"payload"` // JSON envelope sent to subscribers
+    DispatchedAt  *time.Time `gorm:"index" json:"dispatched_at"`        // Set once deliveries were created
+}
+
+// eventEnvelope is the JSON body subscribers receive.
+type eventEnvelope struct {
+    ID        uint        `json:"id"`
+    Type      string      `json:"type"`
+    CreatedAt time.Time   `json:"created_at"`
+    Data      interface{} `json:"data"`
+}
+
+// RecordEvent writes an event to the outbox. Call it with the same *gorm.DB
+// transaction as the business change.
+func RecordEvent(tx *gorm.DB, eventType, aggregateType string, aggregateID uint, data interface{}) error {
+    // A fresh statement on the same connection, so this is safe inside model hooks
+    tx = tx.Session(&gorm.Session{NewDB: true})
+
+    event := OutboxEvent{
+        EventType:     eventType,
+        AggregateType: aggregateType,
+        AggregateID:   aggregateID,
+        Payload:       "{}",
+    }
+    if err := tx.Create(&event).Error; err != nil {
+        return err
+    }
+
+    // The envelope includes the event ID, which only exists after the insert
+    payload, err := json.Marshal(eventEnvelope{ID: event.ID, Type: eventType, CreatedAt: event.CreatedAt, Data: data})
+    if err != nil {
+        return err
+    }
+    return tx.Model(&event).Update("payload", string(payload)).Error
+}
@@ -0,0 +1,50 @@
+// models/pending_action.go
+package models
+
+import (
+    "time"
+)
+
+// Pending action kinds.
+const (
+    ActionReverseTransaction = "transaction.reverse"
+    ActionLargeTransfer      = "transfer.large"
+    ActionDecideLoan         = "loan.decide"
+    ActionAdjustBalance      = "account.adjust"
+)
+
+// Pending action statuses.
+const (
+    ActionStatusPending  = "pending"
+    ActionStatusExecuted = "executed" // Approved and carried out
+    ActionStatusRejected = "rejected"
+    ActionStatusFailed   = "failed" // Approved, but the operation was refused (e.g. insufficient balance)
+    ActionStatusExpired  = "expired"
+)
+
+// PendingAction is an operation proposed by one user (the maker) that only
+// runs once a different, authorized user (the checker) approves it.
+type PendingAction struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+
+    Kind       string `gorm:"index;not null" json:"kind"`
+    EntityType string `gorm:"index:idx_pending_action_entity" json:"entity_type"` // What the action applies to, e.g. "transaction"
+    EntityID   uint   `gorm:"index:idx_pending_action_entity" json:"entity_id"`
+    Payload    string `gorm:"type:text;not null" json:"payload"` // JSON parameters of the operation
+    Reason     string `json:"reason"`                            // Given by the maker
+
+    Status    string     `gorm:"not null;default:pending;index" json:"status"`
+    MakerID   uint       `gorm:"index;not null" json:"maker_id"`
+    CheckerID *uint      `json:"checker_id,omitempty"`
+    DecidedAt *time.Time `json:"decided_at,omitempty"`
+    Note      string     `json:"note,omitempty"`                    // Given by the checker
+    Result    string     `gorm:"type:text" json:"result,omitempty"` // JSON outcome once executed, or the failure message
+    ExpiresAt time.Time  `gorm:"index" json:"expires_at"`
+}
+
+// IsExpired reports whether a pending action can no longer be decided.
+func (a *PendingAction) IsExpired(now time.Time) bool {
+    return a.Status == ActionStatusPending && !now.Before(a.ExpiresAt)
+}
@@ -0,0 +1,12 @@
+// models/rate_limit.go
+package models
+
+import "time"
+
+// RateLimitBucket is the token bucket state for one rate limit key when the
+// limiter uses the shared Postgres store.
+type RateLimitBucket struct {
+    Key        string    `gorm:"primaryKey;size:200"` // e.g. "money:user:42"
+    Tokens     float64   `gorm:"not null"`
+    LastRefill time.Time `gorm:"not null;index"`
+}
@@ -0,0 +1,65 @@
+// models/scheduled_transfer.go
+package models
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// Scheduled transfer frequencies.
+const (
+    FrequencyOnce    = "once"
+    FrequencyDaily   = "daily"
+    FrequencyWeekly  = "weekly"
+    FrequencyMonthly = "monthly" // On DayOfMonth, or the last day of shorter months
+)
+
+// Scheduled transfer statuses.
+const (
+    ScheduleStatusActive    = "active"
+    ScheduleStatusPaused    = "paused"
+    ScheduleStatusCompleted = "completed" // One-off executed, or past the end date
+    ScheduleStatusCancelled = "cancelled"
+    ScheduleStatusFailed    = "failed" // One-off that ran out of retries
+)
+
+// ScheduledTransfer is a standing order: a one-off future transfer or a recurring one.
+type ScheduledTransfer struct {
+    ID        uint           `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time      `json:"created_at"`
+    UpdatedAt time.Time      `json:"updated_at"`
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+    UserID        uint    `gorm:"index;not null" json:"user_id"` // Who created it
+    FromAccountID uint    `gorm:"index;not null" json:"from_account_id"`
+    ToAccountID   uint    `gorm:"not null" json:"to_account_id"`
+    Amount        float64 `gorm:"not null" json:"amount"`
+    Description   string  `json:"description"`
+
+    Frequency  string     `gorm:"not null" json:"frequency"` // once, daily, weekly, monthly
+    DayOfMonth int        `json:"day_of_month,omitempty"`    // Monthly only, 1-31
+    StartAt    time.Time  `json:"start_at"`
+    EndAt      *time.Time `json:"end_at,omitempty"` // No occurrences after this
+
+    Status         string     `gorm:"not null;default:active;index" json:"status"`
+    NextOccurrence *time.Time `json:"next_occurrence,omitempty"`          // Date the pending occurrence is due for
+    NextRunAt      *time.Time `gorm:"index" json:"next_run_at,omitempty"` // When the worker tries next (later than NextOccurrence while retrying)
+    Attempts       int        `json:"attempts"`                           // Failed attempts for the pending occurrence
+    ExecutionCount int        `json:"execution_count"`
+    LastRunAt      *time.Time `json:"last_run_at,omitempty"`
+    LastError      string     `json:"last_error,omitempty"`
+}
+
+// ScheduledTransferRun records every execution attempt, including why it failed.
+type ScheduledTransferRun struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+
+    ScheduledTransferID uint      `gorm:"index;not null" json:"scheduled_transfer_id"`
+    Occurrence          time.Time `json:"occurrence"`
+    Attempt             int       `json:"attempt"`
+    Succeeded           bool      `json:"succeeded"`
+    Error               string    `json:"error,omitempty"`
+    TransactionID       *uint     `json:"transaction_id,omitempty"` // The transfer-out entry on success
+}
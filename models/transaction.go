@@ -14,7 +14,48 @@ type Transaction struct {
     DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
     AccountID       uint    `json:"account_id"`          // Which account this transaction is for
-    TransactionType string  `json:"transaction_type"`    // "deposit", "withdrawal", "transfer"
+    TransactionType string  `json:"transaction_type"`    // See the Transaction* constants
     Amount          float64 `json:"amount"`              // How much money was moved
     Description     string  `json:"description"`         // Optional notes or reason
+    BalanceAfter    float64 `json:"balance_after"`       // Account balance right after this transaction
+
+    CounterpartID *uint `json:"counterpart_id,omitempty"`                    // The other leg of a transfer
+    ReversalOfID  *uint `gorm:"uniqueIndex" json:"reversal_of_id,omitempty"` // The entry this one compensates; each entry is reversed at most once
+}
+
+// Transaction types.
+const (
+    TransactionDeposit           = "deposit"
+    TransactionWithdrawal        = "withdrawal"
+    TransactionTransferOut       = "transfer-out"
+    TransactionTransferIn        = "transfer-in"
+    TransactionReversalOut       = "reversal-out"       // Takes back a credit (deposit, transfer-in)
+    TransactionReversalIn        = "reversal-in"        // Gives back a debit (withdrawal, transfer-out)
+    TransactionAdjustmentIn      = "adjustment-in"      // Manual correction crediting the account
+    TransactionAdjustmentOut     = "adjustment-out"     // Manual correction debiting the account
+    TransactionLoanPayout        = "loan-payout"        // Loan principal, less the origination fee, paid into the account
+    TransactionLoanRepayment     = "loan-repayment"     // Loan installment collected from the account
+    TransactionOverdraftFee      = "overdraft-fee"      // Charged when a debit takes the balance below zero
+    TransactionOverdraftInterest = "overdraft-interest" // Daily interest on a negative balance
+    TransactionHoldCapture       = "hold-capture"       // Settlement of a hold
+)
+
+// IsCredit reports whether the transaction added money to its account.
+func (t *Transaction) IsCredit() bool {
+    switch t.TransactionType {
+    case TransactionDeposit, TransactionTransferIn, TransactionReversalIn, TransactionAdjustmentIn, TransactionLoanPayout:
+        return true
+    }
+    return false
+}
+
+// IsReversal reports whether the transaction is a compensating entry.
+func (t *Transaction) IsReversal() bool {
+    return t.ReversalOfID != nil
+}
+
+// AfterCreate publishes a transaction.created event through the outbox, in the
+// same DB transaction as the insert, wherever the transaction is logged from.
+func (t *Transaction) AfterCreate(tx *gorm.DB) error {
+    return RecordEvent(tx, EventTransactionCreated, "transaction", t.ID, t)
 }
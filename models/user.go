@@ -1,7 +1,18 @@
 // models/user.go
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// User roles. Staff and admins can act on accounts they do not own.
+const (
+	RoleCustomer = "customer"
+	RoleStaff    = "staff"
+	RoleAdmin    = "admin"
+)
 
 // User represents a user in the system.
 type User struct {
@@ -9,4 +20,19 @@ type User struct {
 	Username string `gorm:"unique;not null" json:"username"`
 	Email    string `gorm:"unique;not null" json:"email"`
 	Password string `gorm:"not null" json:"password"`
+	Role     string `gorm:"not null;default:customer" json:"role"`
+
+	// Two-factor authentication (TOTP). These are never bound from or
+	// rendered to JSON; use the /mfa endpoints instead.
+	TOTPSecret   string `json:"-"`                               // Set on enrollment, before confirmation
+	MFAEnabled   bool   `gorm:"not null;default:false" json:"-"` // True once the user confirmed a code
+	TOTPLastStep int64  `json:"-"`                               // Last accepted time step, to stop code replay
+
+	// Set once the user follows the link in the verification email.
+	EmailVerifiedAt *time.Time `json:"-"`
+}
+
+// IsStaff reports whether the user is a staff member or an admin.
+func (u *User) IsStaff() bool {
+	return u.Role == RoleStaff || u.Role == RoleAdmin
 }
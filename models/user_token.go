@@ -0,0 +1,29 @@
+// models/user_token.go
+package models
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// Purposes for UserToken.
+const (
+    TokenPurposeEmailVerification = "email_verification"
+    TokenPurposePasswordReset     = "password_reset"
+)
+
+// UserToken is a single-use, time-limited token sent to the user by email.
+// Only the SHA-256 hash is stored; the plaintext token exists only in the email.
+type UserToken struct {
+    ID        uint           `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time      `json:"created_at"`
+    UpdatedAt time.Time      `json:"updated_at"`
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+    UserID    uint       `gorm:"index;not null" json:"user_id"`
+    Purpose   string     `gorm:"index;not null" json:"purpose"` // "email_verification" or "password_reset"
+    TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+    ExpiresAt time.Time  `json:"expires_at"`
+    UsedAt    *time.Time `json:"used_at"`
+}
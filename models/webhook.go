@@ -0,0 +1,58 @@
+// models/webhook.go
+package models
+
+import (
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+)
+
+// Webhook delivery statuses.
+const (
+    DeliveryStatusPending   = "pending"
+    DeliveryStatusSucceeded = "succeeded"
+    DeliveryStatusDead      = "dead" // Gave up after the maximum number of attempts (dead-letter list)
+)
+
+// WebhookSubscription is an endpoint that receives events.
+type WebhookSubscription struct {
+    ID        uint           `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time      `json:"created_at"`
+    UpdatedAt time.Time      `json:"updated_at"`
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+    UserID     uint   `gorm:"index;not null" json:"user_id"` // Who created it
+    URL        string `gorm:"not null" json:"url"`
+    Secret     string `gorm:"not null" json:"-"`           // HMAC key; only shown once on creation
+    EventTypes string `gorm:"not null" json:"event_types"` // Comma separated, "*" for every event
+    Active     bool   `gorm:"not null;default:true" json:"active"`
+}
+
+// Matches reports whether the subscription wants events of the given type.
+func (s *WebhookSubscription) Matches(eventType string) bool {
+    for _, t := range strings.Split(s.EventTypes, ",") {
+        t = strings.TrimSpace(t)
+        if t == "*" || t == eventType {
+            return true
+        }
+    }
+    return false
+}
+
+// WebhookDelivery is one event sent (or to be sent) to one subscription.
+type WebhookDelivery struct {
+    ID        uint      `gorm:"primaryKey" json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+
+    SubscriptionID uint       `gorm:"index;not null;uniqueIndex:idx_delivery_event" json:"subscription_id"`
+    EventID        uint       `gorm:"not null;uniqueIndex:idx_delivery_event" json:"event_id"`
+    EventType      string     `gorm:"not null" json:"event_type"`
+    Status         string     `gorm:"index;not null;default:pending" json:"status"` // pending, succeeded, dead
+    Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+    NextAttemptAt  time.Time  `gorm:"index" json:"next_attempt_at"`
+    LastStatusCode int        `json:"last_status_code,omitempty"`
+    LastError      string     `json:"last_error,omitempty"`
+    DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
@@ -0,0 +1,1034 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: bank/v1/accounts.proto
+
+package bankv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Product       string                 `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`   // Defaults to "standard"
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"` // Defaults to EUR
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateAccountRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateAccountRequest) GetProduct() string {
+	if x != nil {
+		return x.Product
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ref           string                 `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"` // Account ID, account number or IBAN
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetAccountRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type MoneyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoneyRequest) Reset() {
+	*x = MoneyRequest{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoneyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoneyRequest) ProtoMessage() {}
+
+func (x *MoneyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoneyRequest.ProtoReflect.Descriptor instead.
+func (*MoneyRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MoneyRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *MoneyRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type MoneyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Transaction   *Transaction           `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	OverdraftFee  *Transaction           `protobuf:"bytes,3,opt,name=overdraft_fee,json=overdraftFee,proto3" json:"overdraft_fee,omitempty"` // Set when a withdrawal takes the balance below zero
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoneyResponse) Reset() {
+	*x = MoneyResponse{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoneyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoneyResponse) ProtoMessage() {}
+
+func (x *MoneyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoneyResponse.ProtoReflect.Descriptor instead.
+func (*MoneyResponse) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MoneyResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *MoneyResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *MoneyResponse) GetOverdraftFee() *Transaction {
+	if x != nil {
+		return x.OverdraftFee
+	}
+	return nil
+}
+
+// TransferRequest names each account by ID or by reference (account number,
+// IBAN or ID). An ID wins when both are set.
+type TransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromAccountId uint64                 `protobuf:"varint,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	FromAccount   string                 `protobuf:"bytes,2,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccountId   uint64                 `protobuf:"varint,3,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	ToAccount     string                 `protobuf:"bytes,4,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	MfaCode       string                 `protobuf:"bytes,6,opt,name=mfa_code,json=mfaCode,proto3" json:"mfa_code,omitempty"` // Needed above the step-up threshold
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferRequest) ProtoMessage() {}
+
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransferRequest) GetFromAccountId() uint64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetFromAccount() string {
+	if x != nil {
+		return x.FromAccount
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetToAccountId() uint64 {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetToAccount() string {
+	if x != nil {
+		return x.ToAccount
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetMfaCode() string {
+	if x != nil {
+		return x.MfaCode
+	}
+	return ""
+}
+
+type TransferResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	FromAccount *Account               `protobuf:"bytes,1,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount   *Account               `protobuf:"bytes,2,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	OutTx       *Transaction           `protobuf:"bytes,3,opt,name=out_tx,json=outTx,proto3" json:"out_tx,omitempty"`
+	InTx        *Transaction           `protobuf:"bytes,4,opt,name=in_tx,json=inTx,proto3" json:"in_tx,omitempty"`
+	// Set instead of the fields above when the amount is at or above the
+	// approval threshold: the transfer runs once staff approve this action.
+	PendingAction *PendingAction `protobuf:"bytes,5,opt,name=pending_action,json=pendingAction,proto3" json:"pending_action,omitempty"`
+	OverdraftFee  *Transaction   `protobuf:"bytes,6,opt,name=overdraft_fee,json=overdraftFee,proto3" json:"overdraft_fee,omitempty"` // Set when the transfer takes the source below zero
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferResponse) Reset() {
+	*x = TransferResponse{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferResponse) ProtoMessage() {}
+
+func (x *TransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransferResponse) GetFromAccount() *Account {
+	if x != nil {
+		return x.FromAccount
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetToAccount() *Account {
+	if x != nil {
+		return x.ToAccount
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetOutTx() *Transaction {
+	if x != nil {
+		return x.OutTx
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetInTx() *Transaction {
+	if x != nil {
+		return x.InTx
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetPendingAction() *PendingAction {
+	if x != nil {
+		return x.PendingAction
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetOverdraftFee() *Transaction {
+	if x != nil {
+		return x.OverdraftFee
+	}
+	return nil
+}
+
+type SetOverdraftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Limit         float64                `protobuf:"fixed64,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	InterestRate  *float64               `protobuf:"fixed64,3,opt,name=interest_rate,json=interestRate,proto3,oneof" json:"interest_rate,omitempty"` // Defaults to OVERDRAFT_INTEREST_RATE
+	Fee           *float64               `protobuf:"fixed64,4,opt,name=fee,proto3,oneof" json:"fee,omitempty"`                                       // Defaults to OVERDRAFT_FEE
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetOverdraftRequest) Reset() {
+	*x = SetOverdraftRequest{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetOverdraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetOverdraftRequest) ProtoMessage() {}
+
+func (x *SetOverdraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetOverdraftRequest.ProtoReflect.Descriptor instead.
+func (*SetOverdraftRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetOverdraftRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *SetOverdraftRequest) GetLimit() float64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SetOverdraftRequest) GetInterestRate() float64 {
+	if x != nil && x.InterestRate != nil {
+		return *x.InterestRate
+	}
+	return 0
+}
+
+func (x *SetOverdraftRequest) GetFee() float64 {
+	if x != nil && x.Fee != nil {
+		return *x.Fee
+	}
+	return 0
+}
+
+// Hold mirrors models.Hold.
+type Hold struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId      uint64                 `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount         float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Reference      string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // active, captured, released, expired
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ClosedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	CapturedAmount float64                `protobuf:"fixed64,9,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	TransactionId  uint64                 `protobuf:"varint,10,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // The hold-capture debit, 0 until captured
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Hold) Reset() {
+	*x = Hold{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hold) ProtoMessage() {}
+
+func (x *Hold) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hold.ProtoReflect.Descriptor instead.
+func (*Hold) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Hold) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Hold) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *Hold) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Hold) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Hold) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Hold) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Hold) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Hold) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Hold) GetCapturedAmount() float64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *Hold) GetTransactionId() uint64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *Hold) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Reference     string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`                  // e.g. a card authorization code
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Defaults to HOLD_TTL from now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateHoldRequest) Reset() {
+	*x = CreateHoldRequest{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHoldRequest) ProtoMessage() {}
+
+func (x *CreateHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHoldRequest.ProtoReflect.Descriptor instead.
+func (*CreateHoldRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateHoldRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CreateHoldRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateHoldRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateHoldRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *CreateHoldRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CaptureHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"` // 0 captures the whole hold; less releases the rest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHoldRequest) Reset() {
+	*x = CaptureHoldRequest{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHoldRequest) ProtoMessage() {}
+
+func (x *CaptureHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHoldRequest.ProtoReflect.Descriptor instead.
+func (*CaptureHoldRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CaptureHoldRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CaptureHoldRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type ReleaseHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseHoldRequest) Reset() {
+	*x = ReleaseHoldRequest{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseHoldRequest) ProtoMessage() {}
+
+func (x *ReleaseHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseHoldRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseHoldRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReleaseHoldRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type HoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Hold          *Hold                  `protobuf:"bytes,2,opt,name=hold,proto3" json:"hold,omitempty"`
+	Transaction   *Transaction           `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`                       // Set by CaptureHold
+	OverdraftFee  *Transaction           `protobuf:"bytes,4,opt,name=overdraft_fee,json=overdraftFee,proto3" json:"overdraft_fee,omitempty"` // Set when the capture takes the balance below zero
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldResponse) Reset() {
+	*x = HoldResponse{}
+	mi := &file_bank_v1_accounts_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldResponse) ProtoMessage() {}
+
+func (x *HoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_accounts_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldResponse.ProtoReflect.Descriptor instead.
+func (*HoldResponse) Descriptor() ([]byte, []int) {
+	return file_bank_v1_accounts_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *HoldResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *HoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *HoldResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *HoldResponse) GetOverdraftFee() *Transaction {
+	if x != nil {
+		return x.OverdraftFee
+	}
+	return nil
+}
+
+var File_bank_v1_accounts_proto protoreflect.FileDescriptor
+
+const file_bank_v1_accounts_proto_rawDesc = "" +
+	"\n" +
+	"\x16bank/v1/accounts.proto\x12\abank.v1\x1a\x13bank/v1/types.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"e\n" +
+	"\x14CreateAccountRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x18\n" +
+	"\aproduct\x18\x02 \x01(\tR\aproduct\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"%\n" +
+	"\x11GetAccountRequest\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\tR\x03ref\"E\n" +
+	"\fMoneyRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\xae\x01\n" +
+	"\rMoneyResponse\x12*\n" +
+	"\aaccount\x18\x01 \x01(\v2\x10.bank.v1.AccountR\aaccount\x126\n" +
+	"\vtransaction\x18\x02 \x01(\v2\x14.bank.v1.TransactionR\vtransaction\x129\n" +
+	"\roverdraft_fee\x18\x03 \x01(\v2\x14.bank.v1.TransactionR\foverdraftFee\"\xd2\x01\n" +
+	"\x0fTransferRequest\x12&\n" +
+	"\x0ffrom_account_id\x18\x01 \x01(\x04R\rfromAccountId\x12!\n" +
+	"\ffrom_account\x18\x02 \x01(\tR\vfromAccount\x12\"\n" +
+	"\rto_account_id\x18\x03 \x01(\x04R\vtoAccountId\x12\x1d\n" +
+	"\n" +
+	"to_account\x18\x04 \x01(\tR\ttoAccount\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x19\n" +
+	"\bmfa_code\x18\x06 \x01(\tR\amfaCode\"\xca\x02\n" +
+	"\x10TransferResponse\x123\n" +
+	"\ffrom_account\x18\x01 \x01(\v2\x10.bank.v1.AccountR\vfromAccount\x12/\n" +
+	"\n" +
+	"to_account\x18\x02 \x01(\v2\x10.bank.v1.AccountR\ttoAccount\x12+\n" +
+	"\x06out_tx\x18\x03 \x01(\v2\x14.bank.v1.TransactionR\x05outTx\x12)\n" +
+	"\x05in_tx\x18\x04 \x01(\v2\x14.bank.v1.TransactionR\x04inTx\x12=\n" +
+	"\x0epending_action\x18\x05 \x01(\v2\x16.bank.v1.PendingActionR\rpendingAction\x129\n" +
+	"\roverdraft_fee\x18\x06 \x01(\v2\x14.bank.v1.TransactionR\foverdraftFee\"\xa5\x01\n" +
+	"\x13SetOverdraftRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x01R\x05limit\x12(\n" +
+	"\rinterest_rate\x18\x03 \x01(\x01H\x00R\finterestRate\x88\x01\x01\x12\x15\n" +
+	"\x03fee\x18\x04 \x01(\x01H\x01R\x03fee\x88\x01\x01B\x10\n" +
+	"\x0e_interest_rateB\x06\n" +
+	"\x04_fee\"\xa4\x03\n" +
+	"\x04Hold\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\x04R\taccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x127\n" +
+	"\tclosed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12'\n" +
+	"\x0fcaptured_amount\x18\t \x01(\x01R\x0ecapturedAmount\x12%\n" +
+	"\x0etransaction_id\x18\n" +
+	" \x01(\x04R\rtransactionId\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc5\x01\n" +
+	"\x11CreateHoldRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"<\n" +
+	"\x12CaptureHoldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"$\n" +
+	"\x12ReleaseHoldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\xd0\x01\n" +
+	"\fHoldResponse\x12*\n" +
+	"\aaccount\x18\x01 \x01(\v2\x10.bank.v1.AccountR\aaccount\x12!\n" +
+	"\x04hold\x18\x02 \x01(\v2\r.bank.v1.HoldR\x04hold\x126\n" +
+	"\vtransaction\x18\x03 \x01(\v2\x14.bank.v1.TransactionR\vtransaction\x129\n" +
+	"\roverdraft_fee\x18\x04 \x01(\v2\x14.bank.v1.TransactionR\foverdraftFee2\xcb\x04\n" +
+	"\x0eAccountService\x12@\n" +
+	"\rCreateAccount\x12\x1d.bank.v1.CreateAccountRequest\x1a\x10.bank.v1.Account\x12:\n" +
+	"\n" +
+	"GetAccount\x12\x1a.bank.v1.GetAccountRequest\x1a\x10.bank.v1.Account\x128\n" +
+	"\aDeposit\x12\x15.bank.v1.MoneyRequest\x1a\x16.bank.v1.MoneyResponse\x129\n" +
+	"\bWithdraw\x12\x15.bank.v1.MoneyRequest\x1a\x16.bank.v1.MoneyResponse\x12?\n" +
+	"\bTransfer\x12\x18.bank.v1.TransferRequest\x1a\x19.bank.v1.TransferResponse\x12>\n" +
+	"\fSetOverdraft\x12\x1c.bank.v1.SetOverdraftRequest\x1a\x10.bank.v1.Account\x12?\n" +
+	"\n" +
+	"CreateHold\x12\x1a.bank.v1.CreateHoldRequest\x1a\x15.bank.v1.HoldResponse\x12A\n" +
+	"\vCaptureHold\x12\x1b.bank.v1.CaptureHoldRequest\x1a\x15.bank.v1.HoldResponse\x12A\n" +
+	"\vReleaseHold\x12\x1b.bank.v1.ReleaseHoldRequest\x1a\x15.bank.v1.HoldResponseBAZ?github.com/bhushangupta162/bank_management/proto/bank/v1;bankv1b\x06proto3"
+
+var (
+	file_bank_v1_accounts_proto_rawDescOnce sync.Once
+	file_bank_v1_accounts_proto_rawDescData []byte
+)
+
+func file_bank_v1_accounts_proto_rawDescGZIP() []byte {
+	file_bank_v1_accounts_proto_rawDescOnce.Do(func() {
+		file_bank_v1_accounts_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bank_v1_accounts_proto_rawDesc), len(file_bank_v1_accounts_proto_rawDesc)))
+	})
+	return file_bank_v1_accounts_proto_rawDescData
+}
+
+var file_bank_v1_accounts_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_bank_v1_accounts_proto_goTypes = []any{
+	(*CreateAccountRequest)(nil),  // 0: bank.v1.CreateAccountRequest
+	(*GetAccountRequest)(nil),     // 1: bank.v1.GetAccountRequest
+	(*MoneyRequest)(nil),          // 2: bank.v1.MoneyRequest
+	(*MoneyResponse)(nil),         // 3: bank.v1.MoneyResponse
+	(*TransferRequest)(nil),       // 4: bank.v1.TransferRequest
+	(*TransferResponse)(nil),      // 5: bank.v1.TransferResponse
+	(*SetOverdraftRequest)(nil),   // 6: bank.v1.SetOverdraftRequest
+	(*Hold)(nil),                  // 7: bank.v1.Hold
+	(*CreateHoldRequest)(nil),     // 8: bank.v1.CreateHoldRequest
+	(*CaptureHoldRequest)(nil),    // 9: bank.v1.CaptureHoldRequest
+	(*ReleaseHoldRequest)(nil),    // 10: bank.v1.ReleaseHoldRequest
+	(*HoldResponse)(nil),          // 11: bank.v1.HoldResponse
+	(*Account)(nil),               // 12: bank.v1.Account
+	(*Transaction)(nil),           // 13: bank.v1.Transaction
+	(*PendingAction)(nil),         // 14: bank.v1.PendingAction
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_bank_v1_accounts_proto_depIdxs = []int32{
+	12, // 0: bank.v1.MoneyResponse.account:type_name -> bank.v1.Account
+	13, // 1: bank.v1.MoneyResponse.transaction:type_name -> bank.v1.Transaction
+	13, // 2: bank.v1.MoneyResponse.overdraft_fee:type_name -> bank.v1.Transaction
+	12, // 3: bank.v1.TransferResponse.from_account:type_name -> bank.v1.Account
+	12, // 4: bank.v1.TransferResponse.to_account:type_name -> bank.v1.Account
+	13, // 5: bank.v1.TransferResponse.out_tx:type_name -> bank.v1.Transaction
+	13, // 6: bank.v1.TransferResponse.in_tx:type_name -> bank.v1.Transaction
+	14, // 7: bank.v1.TransferResponse.pending_action:type_name -> bank.v1.PendingAction
+	13, // 8: bank.v1.TransferResponse.overdraft_fee:type_name -> bank.v1.Transaction
+	15, // 9: bank.v1.Hold.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 10: bank.v1.Hold.closed_at:type_name -> google.protobuf.Timestamp
+	15, // 11: bank.v1.Hold.created_at:type_name -> google.protobuf.Timestamp
+	15, // 12: bank.v1.CreateHoldRequest.expires_at:type_name -> google.protobuf.Timestamp
+	12, // 13: bank.v1.HoldResponse.account:type_name -> bank.v1.Account
+	7,  // 14: bank.v1.HoldResponse.hold:type_name -> bank.v1.Hold
+	13, // 15: bank.v1.HoldResponse.transaction:type_name -> bank.v1.Transaction
+	13, // 16: bank.v1.HoldResponse.overdraft_fee:type_name -> bank.v1.Transaction
+	0,  // 17: bank.v1.AccountService.CreateAccount:input_type -> bank.v1.CreateAccountRequest
+	1,  // 18: bank.v1.AccountService.GetAccount:input_type -> bank.v1.GetAccountRequest
+	2,  // 19: bank.v1.AccountService.Deposit:input_type -> bank.v1.MoneyRequest
+	2,  // 20: bank.v1.AccountService.Withdraw:input_type -> bank.v1.MoneyRequest
+	4,  // 21: bank.v1.AccountService.Transfer:input_type -> bank.v1.TransferRequest
+	6,  // 22: bank.v1.AccountService.SetOverdraft:input_type -> bank.v1.SetOverdraftRequest
+	8,  // 23: bank.v1.AccountService.CreateHold:input_type -> bank.v1.CreateHoldRequest
+	9,  // 24: bank.v1.AccountService.CaptureHold:input_type -> bank.v1.CaptureHoldRequest
+	10, // 25: bank.v1.AccountService.ReleaseHold:input_type -> bank.v1.ReleaseHoldRequest
+	12, // 26: bank.v1.AccountService.CreateAccount:output_type -> bank.v1.Account
+	12, // 27: bank.v1.AccountService.GetAccount:output_type -> bank.v1.Account
+	3,  // 28: bank.v1.AccountService.Deposit:output_type -> bank.v1.MoneyResponse
+	3,  // 29: bank.v1.AccountService.Withdraw:output_type -> bank.v1.MoneyResponse
+	5,  // 30: bank.v1.AccountService.Transfer:output_type -> bank.v1.TransferResponse
+	12, // 31: bank.v1.AccountService.SetOverdraft:output_type -> bank.v1.Account
+	11, // 32: bank.v1.AccountService.CreateHold:output_type -> bank.v1.HoldResponse
+	11, // 33: bank.v1.AccountService.CaptureHold:output_type -> bank.v1.HoldResponse
+	11, // 34: bank.v1.AccountService.ReleaseHold:output_type -> bank.v1.HoldResponse
+	26, // [26:35] is the sub-list for method output_type
+	17, // [17:26] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_bank_v1_accounts_proto_init() }
+func file_bank_v1_accounts_proto_init() {
+	if File_bank_v1_accounts_proto != nil {
+		return
+	}
+	file_bank_v1_types_proto_init()
+	file_bank_v1_accounts_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bank_v1_accounts_proto_rawDesc), len(file_bank_v1_accounts_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bank_v1_accounts_proto_goTypes,
+		DependencyIndexes: file_bank_v1_accounts_proto_depIdxs,
+		MessageInfos:      file_bank_v1_accounts_proto_msgTypes,
+	}.Build()
+	File_bank_v1_accounts_proto = out.File
+	file_bank_v1_accounts_proto_goTypes = nil
+	file_bank_v1_accounts_proto_depIdxs = nil
+}
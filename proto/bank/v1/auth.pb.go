@@ -0,0 +1,270 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: bank/v1/auth.proto
+
+package bankv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_bank_v1_auth_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_auth_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_auth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// LoginResponse holds an access token, or an MFA challenge when the user has
+// two-factor authentication enabled.
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	MfaRequired   bool                   `protobuf:"varint,2,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,3,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"` // Exchange with LoginMFA
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_bank_v1_auth_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_auth_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_bank_v1_auth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LoginResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *LoginResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+type LoginMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MfaToken      string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                                     // Current TOTP code
+	RecoveryCode  string                 `protobuf:"bytes,3,opt,name=recovery_code,json=recoveryCode,proto3" json:"recovery_code,omitempty"` // Instead of code, if the authenticator is lost
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginMFARequest) Reset() {
+	*x = LoginMFARequest{}
+	mi := &file_bank_v1_auth_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginMFARequest) ProtoMessage() {}
+
+func (x *LoginMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_auth_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginMFARequest.ProtoReflect.Descriptor instead.
+func (*LoginMFARequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_auth_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LoginMFARequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *LoginMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LoginMFARequest) GetRecoveryCode() string {
+	if x != nil {
+		return x.RecoveryCode
+	}
+	return ""
+}
+
+var File_bank_v1_auth_proto protoreflect.FileDescriptor
+
+const file_bank_v1_auth_proto_rawDesc = "" +
+	"\n" +
+	"\x12bank/v1/auth.proto\x12\abank.v1\"@\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"e\n" +
+	"\rLoginResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fmfa_required\x18\x02 \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\x03 \x01(\tR\bmfaToken\"g\n" +
+	"\x0fLoginMFARequest\x12\x1b\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12#\n" +
+	"\rrecovery_code\x18\x03 \x01(\tR\frecoveryCode2\x83\x01\n" +
+	"\vAuthService\x126\n" +
+	"\x05Login\x12\x15.bank.v1.LoginRequest\x1a\x16.bank.v1.LoginResponse\x12<\n" +
+	"\bLoginMFA\x12\x18.bank.v1.LoginMFARequest\x1a\x16.bank.v1.LoginResponseBAZ?github.com/bhushangupta162/bank_management/proto/bank/v1;bankv1b\x06proto3"
+
+var (
+	file_bank_v1_auth_proto_rawDescOnce sync.Once
+	file_bank_v1_auth_proto_rawDescData []byte
+)
+
+func file_bank_v1_auth_proto_rawDescGZIP() []byte {
+	file_bank_v1_auth_proto_rawDescOnce.Do(func() {
+		file_bank_v1_auth_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bank_v1_auth_proto_rawDesc), len(file_bank_v1_auth_proto_rawDesc)))
+	})
+	return file_bank_v1_auth_proto_rawDescData
+}
+
+var file_bank_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_bank_v1_auth_proto_goTypes = []any{
+	(*LoginRequest)(nil),    // 0: bank.v1.LoginRequest
+	(*LoginResponse)(nil),   // 1: bank.v1.LoginResponse
+	(*LoginMFARequest)(nil), // 2: bank.v1.LoginMFARequest
+}
+var file_bank_v1_auth_proto_depIdxs = []int32{
+	0, // 0: bank.v1.AuthService.Login:input_type -> bank.v1.LoginRequest
+	2, // 1: bank.v1.AuthService.LoginMFA:input_type -> bank.v1.LoginMFARequest
+	1, // 2: bank.v1.AuthService.Login:output_type -> bank.v1.LoginResponse
+	1, // 3: bank.v1.AuthService.LoginMFA:output_type -> bank.v1.LoginResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_bank_v1_auth_proto_init() }
+func file_bank_v1_auth_proto_init() {
+	if File_bank_v1_auth_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bank_v1_auth_proto_rawDesc), len(file_bank_v1_auth_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bank_v1_auth_proto_goTypes,
+		DependencyIndexes: file_bank_v1_auth_proto_depIdxs,
+		MessageInfos:      file_bank_v1_auth_proto_msgTypes,
+	}.Build()
+	File_bank_v1_auth_proto = out.File
+	file_bank_v1_auth_proto_goTypes = nil
+	file_bank_v1_auth_proto_depIdxs = nil
+}
@@ -0,0 +1,633 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: bank/v1/loans.proto
+
+package bankv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ApplyLoanRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Principal float64                `protobuf:"fixed64,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	// Deprecated: Marked as deprecated in bank/v1/loans.proto.
+	InterestRate  float64 `protobuf:"fixed64,3,opt,name=interest_rate,json=interestRate,proto3" json:"interest_rate,omitempty"` // Ignored: underwriting prices the rate
+	TermMonths    int32   `protobuf:"varint,4,opt,name=term_months,json=termMonths,proto3" json:"term_months,omitempty"`
+	ProductId     uint64  `protobuf:"varint,5,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // Required; the catalog is at GET /api/v1/loan-products
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyLoanRequest) Reset() {
+	*x = ApplyLoanRequest{}
+	mi := &file_bank_v1_loans_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyLoanRequest) ProtoMessage() {}
+
+func (x *ApplyLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_loans_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyLoanRequest.ProtoReflect.Descriptor instead.
+func (*ApplyLoanRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_loans_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApplyLoanRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ApplyLoanRequest) GetPrincipal() float64 {
+	if x != nil {
+		return x.Principal
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in bank/v1/loans.proto.
+func (x *ApplyLoanRequest) GetInterestRate() float64 {
+	if x != nil {
+		return x.InterestRate
+	}
+	return 0
+}
+
+func (x *ApplyLoanRequest) GetTermMonths() int32 {
+	if x != nil {
+		return x.TermMonths
+	}
+	return 0
+}
+
+func (x *ApplyLoanRequest) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+type GetLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoanRequest) Reset() {
+	*x = GetLoanRequest{}
+	mi := &file_bank_v1_loans_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoanRequest) ProtoMessage() {}
+
+func (x *GetLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_loans_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoanRequest.ProtoReflect.Descriptor instead.
+func (*GetLoanRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_loans_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLoanRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DecideLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // approved, rejected, cancelled, defaulted or written_off
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideLoanRequest) Reset() {
+	*x = DecideLoanRequest{}
+	mi := &file_bank_v1_loans_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideLoanRequest) ProtoMessage() {}
+
+func (x *DecideLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_loans_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideLoanRequest.ProtoReflect.Descriptor instead.
+func (*DecideLoanRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_loans_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DecideLoanRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DecideLoanRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type DisburseLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId     uint64                 `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // Must belong to the borrower
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisburseLoanRequest) Reset() {
+	*x = DisburseLoanRequest{}
+	mi := &file_bank_v1_loans_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisburseLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisburseLoanRequest) ProtoMessage() {}
+
+func (x *DisburseLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_loans_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisburseLoanRequest.ProtoReflect.Descriptor instead.
+func (*DisburseLoanRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_loans_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DisburseLoanRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DisburseLoanRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type RepayLoanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Prepayment    string                 `protobuf:"bytes,3,opt,name=prepayment,proto3" json:"prepayment,omitempty"` // shorten_term or reduce_installment; the server default when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepayLoanRequest) Reset() {
+	*x = RepayLoanRequest{}
+	mi := &file_bank_v1_loans_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepayLoanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepayLoanRequest) ProtoMessage() {}
+
+func (x *RepayLoanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_loans_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepayLoanRequest.ProtoReflect.Descriptor instead.
+func (*RepayLoanRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_loans_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RepayLoanRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RepayLoanRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RepayLoanRequest) GetPrepayment() string {
+	if x != nil {
+		return x.Prepayment
+	}
+	return ""
+}
+
+type GetPayoffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"` // Now when unset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPayoffRequest) Reset() {
+	*x = GetPayoffRequest{}
+	mi := &file_bank_v1_loans_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPayoffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPayoffRequest) ProtoMessage() {}
+
+func (x *GetPayoffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_loans_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPayoffRequest.ProtoReflect.Descriptor instead.
+func (*GetPayoffRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_loans_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetPayoffRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetPayoffRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+type SetRepaymentAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId     uint64                 `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // Must belong to the borrower
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRepaymentAccountRequest) Reset() {
+	*x = SetRepaymentAccountRequest{}
+	mi := &file_bank_v1_loans_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRepaymentAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRepaymentAccountRequest) ProtoMessage() {}
+
+func (x *SetRepaymentAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_loans_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRepaymentAccountRequest.ProtoReflect.Descriptor instead.
+func (*SetRepaymentAccountRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_loans_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetRepaymentAccountRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetRepaymentAccountRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type PayoffQuote struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	LoanId            uint64                 `protobuf:"varint,1,opt,name=loan_id,json=loanId,proto3" json:"loan_id,omitempty"`
+	AsOf              *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	Principal         float64                `protobuf:"fixed64,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	AccruedInterest   float64                `protobuf:"fixed64,4,opt,name=accrued_interest,json=accruedInterest,proto3" json:"accrued_interest,omitempty"`
+	Fees              float64                `protobuf:"fixed64,5,opt,name=fees,proto3" json:"fees,omitempty"`
+	PrepaymentPenalty float64                `protobuf:"fixed64,6,opt,name=prepayment_penalty,json=prepaymentPenalty,proto3" json:"prepayment_penalty,omitempty"`
+	Total             float64                `protobuf:"fixed64,7,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PayoffQuote) Reset() {
+	*x = PayoffQuote{}
+	mi := &file_bank_v1_loans_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayoffQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayoffQuote) ProtoMessage() {}
+
+func (x *PayoffQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_loans_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayoffQuote.ProtoReflect.Descriptor instead.
+func (*PayoffQuote) Descriptor() ([]byte, []int) {
+	return file_bank_v1_loans_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PayoffQuote) GetLoanId() uint64 {
+	if x != nil {
+		return x.LoanId
+	}
+	return 0
+}
+
+func (x *PayoffQuote) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *PayoffQuote) GetPrincipal() float64 {
+	if x != nil {
+		return x.Principal
+	}
+	return 0
+}
+
+func (x *PayoffQuote) GetAccruedInterest() float64 {
+	if x != nil {
+		return x.AccruedInterest
+	}
+	return 0
+}
+
+func (x *PayoffQuote) GetFees() float64 {
+	if x != nil {
+		return x.Fees
+	}
+	return 0
+}
+
+func (x *PayoffQuote) GetPrepaymentPenalty() float64 {
+	if x != nil {
+		return x.PrepaymentPenalty
+	}
+	return 0
+}
+
+func (x *PayoffQuote) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_bank_v1_loans_proto protoreflect.FileDescriptor
+
+const file_bank_v1_loans_proto_rawDesc = "" +
+	"\n" +
+	"\x13bank/v1/loans.proto\x12\abank.v1\x1a\x13bank/v1/types.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb2\x01\n" +
+	"\x10ApplyLoanRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x1c\n" +
+	"\tprincipal\x18\x02 \x01(\x01R\tprincipal\x12'\n" +
+	"\rinterest_rate\x18\x03 \x01(\x01B\x02\x18\x01R\finterestRate\x12\x1f\n" +
+	"\vterm_months\x18\x04 \x01(\x05R\n" +
+	"termMonths\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x05 \x01(\x04R\tproductId\" \n" +
+	"\x0eGetLoanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\";\n" +
+	"\x11DecideLoanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"D\n" +
+	"\x13DisburseLoanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\x04R\taccountId\"Z\n" +
+	"\x10RepayLoanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1e\n" +
+	"\n" +
+	"prepayment\x18\x03 \x01(\tR\n" +
+	"prepayment\"R\n" +
+	"\x10GetPayoffRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12.\n" +
+	"\x04date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"K\n" +
+	"\x1aSetRepaymentAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\x04R\taccountId\"\xf9\x01\n" +
+	"\vPayoffQuote\x12\x17\n" +
+	"\aloan_id\x18\x01 \x01(\x04R\x06loanId\x12/\n" +
+	"\x05as_of\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12\x1c\n" +
+	"\tprincipal\x18\x03 \x01(\x01R\tprincipal\x12)\n" +
+	"\x10accrued_interest\x18\x04 \x01(\x01R\x0faccruedInterest\x12\x12\n" +
+	"\x04fees\x18\x05 \x01(\x01R\x04fees\x12-\n" +
+	"\x12prepayment_penalty\x18\x06 \x01(\x01R\x11prepaymentPenalty\x12\x14\n" +
+	"\x05total\x18\a \x01(\x01R\x05total2\xb6\x03\n" +
+	"\vLoanService\x125\n" +
+	"\tApplyLoan\x12\x19.bank.v1.ApplyLoanRequest\x1a\r.bank.v1.Loan\x121\n" +
+	"\aGetLoan\x12\x17.bank.v1.GetLoanRequest\x1a\r.bank.v1.Loan\x12@\n" +
+	"\n" +
+	"DecideLoan\x12\x1a.bank.v1.DecideLoanRequest\x1a\x16.bank.v1.PendingAction\x12;\n" +
+	"\fDisburseLoan\x12\x1c.bank.v1.DisburseLoanRequest\x1a\r.bank.v1.Loan\x125\n" +
+	"\tRepayLoan\x12\x19.bank.v1.RepayLoanRequest\x1a\r.bank.v1.Loan\x12<\n" +
+	"\tGetPayoff\x12\x19.bank.v1.GetPayoffRequest\x1a\x14.bank.v1.PayoffQuote\x12I\n" +
+	"\x13SetRepaymentAccount\x12#.bank.v1.SetRepaymentAccountRequest\x1a\r.bank.v1.LoanBAZ?github.com/bhushangupta162/bank_management/proto/bank/v1;bankv1b\x06proto3"
+
+var (
+	file_bank_v1_loans_proto_rawDescOnce sync.Once
+	file_bank_v1_loans_proto_rawDescData []byte
+)
+
+func file_bank_v1_loans_proto_rawDescGZIP() []byte {
+	file_bank_v1_loans_proto_rawDescOnce.Do(func() {
+		file_bank_v1_loans_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bank_v1_loans_proto_rawDesc), len(file_bank_v1_loans_proto_rawDesc)))
+	})
+	return file_bank_v1_loans_proto_rawDescData
+}
+
+var file_bank_v1_loans_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_bank_v1_loans_proto_goTypes = []any{
+	(*ApplyLoanRequest)(nil),           // 0: bank.v1.ApplyLoanRequest
+	(*GetLoanRequest)(nil),             // 1: bank.v1.GetLoanRequest
+	(*DecideLoanRequest)(nil),          // 2: bank.v1.DecideLoanRequest
+	(*DisburseLoanRequest)(nil),        // 3: bank.v1.DisburseLoanRequest
+	(*RepayLoanRequest)(nil),           // 4: bank.v1.RepayLoanRequest
+	(*GetPayoffRequest)(nil),           // 5: bank.v1.GetPayoffRequest
+	(*SetRepaymentAccountRequest)(nil), // 6: bank.v1.SetRepaymentAccountRequest
+	(*PayoffQuote)(nil),                // 7: bank.v1.PayoffQuote
+	(*timestamppb.Timestamp)(nil),      // 8: google.protobuf.Timestamp
+	(*Loan)(nil),                       // 9: bank.v1.Loan
+	(*PendingAction)(nil),              // 10: bank.v1.PendingAction
+}
+var file_bank_v1_loans_proto_depIdxs = []int32{
+	8,  // 0: bank.v1.GetPayoffRequest.date:type_name -> google.protobuf.Timestamp
+	8,  // 1: bank.v1.PayoffQuote.as_of:type_name -> google.protobuf.Timestamp
+	0,  // 2: bank.v1.LoanService.ApplyLoan:input_type -> bank.v1.ApplyLoanRequest
+	1,  // 3: bank.v1.LoanService.GetLoan:input_type -> bank.v1.GetLoanRequest
+	2,  // 4: bank.v1.LoanService.DecideLoan:input_type -> bank.v1.DecideLoanRequest
+	3,  // 5: bank.v1.LoanService.DisburseLoan:input_type -> bank.v1.DisburseLoanRequest
+	4,  // 6: bank.v1.LoanService.RepayLoan:input_type -> bank.v1.RepayLoanRequest
+	5,  // 7: bank.v1.LoanService.GetPayoff:input_type -> bank.v1.GetPayoffRequest
+	6,  // 8: bank.v1.LoanService.SetRepaymentAccount:input_type -> bank.v1.SetRepaymentAccountRequest
+	9,  // 9: bank.v1.LoanService.ApplyLoan:output_type -> bank.v1.Loan
+	9,  // 10: bank.v1.LoanService.GetLoan:output_type -> bank.v1.Loan
+	10, // 11: bank.v1.LoanService.DecideLoan:output_type -> bank.v1.PendingAction
+	9,  // 12: bank.v1.LoanService.DisburseLoan:output_type -> bank.v1.Loan
+	9,  // 13: bank.v1.LoanService.RepayLoan:output_type -> bank.v1.Loan
+	7,  // 14: bank.v1.LoanService.GetPayoff:output_type -> bank.v1.PayoffQuote
+	9,  // 15: bank.v1.LoanService.SetRepaymentAccount:output_type -> bank.v1.Loan
+	9,  // [9:16] is the sub-list for method output_type
+	2,  // [2:9] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_bank_v1_loans_proto_init() }
+func file_bank_v1_loans_proto_init() {
+	if File_bank_v1_loans_proto != nil {
+		return
+	}
+	file_bank_v1_types_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bank_v1_loans_proto_rawDesc), len(file_bank_v1_loans_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bank_v1_loans_proto_goTypes,
+		DependencyIndexes: file_bank_v1_loans_proto_depIdxs,
+		MessageInfos:      file_bank_v1_loans_proto_msgTypes,
+	}.Build()
+	File_bank_v1_loans_proto = out.File
+	file_bank_v1_loans_proto_goTypes = nil
+	file_bank_v1_loans_proto_depIdxs = nil
+}
@@ -0,0 +1,371 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bank/v1/loans.proto
+
+package bankv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LoanService_ApplyLoan_FullMethodName           = "/bank.v1.LoanService/ApplyLoan"
+	LoanService_GetLoan_FullMethodName             = "/bank.v1.LoanService/GetLoan"
+	LoanService_DecideLoan_FullMethodName          = "/bank.v1.LoanService/DecideLoan"
+	LoanService_DisburseLoan_FullMethodName        = "/bank.v1.LoanService/DisburseLoan"
+	LoanService_RepayLoan_FullMethodName           = "/bank.v1.LoanService/RepayLoan"
+	LoanService_GetPayoff_FullMethodName           = "/bank.v1.LoanService/GetPayoff"
+	LoanService_SetRepaymentAccount_FullMethodName = "/bank.v1.LoanService/SetRepaymentAccount"
+)
+
+// LoanServiceClient is the client API for LoanService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LoanService mirrors the loan routes of the REST API.
+type LoanServiceClient interface {
+	ApplyLoan(ctx context.Context, in *ApplyLoanRequest, opts ...grpc.CallOption) (*Loan, error)
+	GetLoan(ctx context.Context, in *GetLoanRequest, opts ...grpc.CallOption) (*Loan, error)
+	// DecideLoan proposes a status change (approve, reject, cancel, default,
+	// write off). Staff only. The decision takes effect once a second staff
+	// member approves the returned action.
+	DecideLoan(ctx context.Context, in *DecideLoanRequest, opts ...grpc.CallOption) (*PendingAction, error)
+	// DisburseLoan pays an approved loan out into one of the borrower's
+	// accounts and activates it. Staff only.
+	DisburseLoan(ctx context.Context, in *DisburseLoanRequest, opts ...grpc.CallOption) (*Loan, error)
+	// RepayLoan pays what is due first; the rest prepays principal.
+	RepayLoan(ctx context.Context, in *RepayLoanRequest, opts ...grpc.CallOption) (*Loan, error)
+	// GetPayoff quotes what it costs to close the loan on a date.
+	GetPayoff(ctx context.Context, in *GetPayoffRequest, opts ...grpc.CallOption) (*PayoffQuote, error)
+	// SetRepaymentAccount sets the account due installments are collected
+	// from by direct debit; account_id 0 stops direct debit.
+	SetRepaymentAccount(ctx context.Context, in *SetRepaymentAccountRequest, opts ...grpc.CallOption) (*Loan, error)
+}
+
+type loanServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLoanServiceClient(cc grpc.ClientConnInterface) LoanServiceClient {
+	return &loanServiceClient{cc}
+}
+
+func (c *loanServiceClient) ApplyLoan(ctx context.Context, in *ApplyLoanRequest, opts ...grpc.CallOption) (*Loan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Loan)
+	err := c.cc.Invoke(ctx, LoanService_ApplyLoan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loanServiceClient) GetLoan(ctx context.Context, in *GetLoanRequest, opts ...grpc.CallOption) (*Loan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Loan)
+	err := c.cc.Invoke(ctx, LoanService_GetLoan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loanServiceClient) DecideLoan(ctx context.Context, in *DecideLoanRequest, opts ...grpc.CallOption) (*PendingAction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PendingAction)
+	err := c.cc.Invoke(ctx, LoanService_DecideLoan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loanServiceClient) DisburseLoan(ctx context.Context, in *DisburseLoanRequest, opts ...grpc.CallOption) (*Loan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Loan)
+	err := c.cc.Invoke(ctx, LoanService_DisburseLoan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loanServiceClient) RepayLoan(ctx context.Context, in *RepayLoanRequest, opts ...grpc.CallOption) (*Loan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Loan)
+	err := c.cc.Invoke(ctx, LoanService_RepayLoan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loanServiceClient) GetPayoff(ctx context.Context, in *GetPayoffRequest, opts ...grpc.CallOption) (*PayoffQuote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PayoffQuote)
+	err := c.cc.Invoke(ctx, LoanService_GetPayoff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *loanServiceClient) SetRepaymentAccount(ctx context.Context, in *SetRepaymentAccountRequest, opts ...grpc.CallOption) (*Loan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Loan)
+	err := c.cc.Invoke(ctx, LoanService_SetRepaymentAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LoanServiceServer is the server API for LoanService service.
+// All implementations must embed UnimplementedLoanServiceServer
+// for forward compatibility.
+//
+// LoanService mirrors the loan routes of the REST API.
+type LoanServiceServer interface {
+	ApplyLoan(context.Context, *ApplyLoanRequest) (*Loan, error)
+	GetLoan(context.Context, *GetLoanRequest) (*Loan, error)
+	// DecideLoan proposes a status change (approve, reject, cancel, default,
+	// write off). Staff only. The decision takes effect once a second staff
+	// member approves the returned action.
+	DecideLoan(context.Context, *DecideLoanRequest) (*PendingAction, error)
+	// DisburseLoan pays an approved loan out into one of the borrower's
+	// accounts and activates it. Staff only.
+	DisburseLoan(context.Context, *DisburseLoanRequest) (*Loan, error)
+	// RepayLoan pays what is due first; the rest prepays principal.
+	RepayLoan(context.Context, *RepayLoanRequest) (*Loan, error)
+	// GetPayoff quotes what it costs to close the loan on a date.
+	GetPayoff(context.Context, *GetPayoffRequest) (*PayoffQuote, error)
+	// SetRepaymentAccount sets the account due installments are collected
+	// from by direct debit; account_id 0 stops direct debit.
+	SetRepaymentAccount(context.Context, *SetRepaymentAccountRequest) (*Loan, error)
+	mustEmbedUnimplementedLoanServiceServer()
+}
+
+// UnimplementedLoanServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLoanServiceServer struct{}
+
+func (UnimplementedLoanServiceServer) ApplyLoan(context.Context, *ApplyLoanRequest) (*Loan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyLoan not implemented")
+}
+func (UnimplementedLoanServiceServer) GetLoan(context.Context, *GetLoanRequest) (*Loan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoan not implemented")
+}
+func (UnimplementedLoanServiceServer) DecideLoan(context.Context, *DecideLoanRequest) (*PendingAction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideLoan not implemented")
+}
+func (UnimplementedLoanServiceServer) DisburseLoan(context.Context, *DisburseLoanRequest) (*Loan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisburseLoan not implemented")
+}
+func (UnimplementedLoanServiceServer) RepayLoan(context.Context, *RepayLoanRequest) (*Loan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepayLoan not implemented")
+}
+func (UnimplementedLoanServiceServer) GetPayoff(context.Context, *GetPayoffRequest) (*PayoffQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPayoff not implemented")
+}
+func (UnimplementedLoanServiceServer) SetRepaymentAccount(context.Context, *SetRepaymentAccountRequest) (*Loan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRepaymentAccount not implemented")
+}
+func (UnimplementedLoanServiceServer) mustEmbedUnimplementedLoanServiceServer() {}
+func (UnimplementedLoanServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeLoanServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LoanServiceServer will
+// result in compilation errors.
+type UnsafeLoanServiceServer interface {
+	mustEmbedUnimplementedLoanServiceServer()
+}
+
+func RegisterLoanServiceServer(s grpc.ServiceRegistrar, srv LoanServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLoanServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LoanService_ServiceDesc, srv)
+}
+
+func _LoanService_ApplyLoan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyLoanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoanServiceServer).ApplyLoan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoanService_ApplyLoan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoanServiceServer).ApplyLoan(ctx, req.(*ApplyLoanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoanService_GetLoan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoanServiceServer).GetLoan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoanService_GetLoan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoanServiceServer).GetLoan(ctx, req.(*GetLoanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoanService_DecideLoan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideLoanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoanServiceServer).DecideLoan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoanService_DecideLoan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoanServiceServer).DecideLoan(ctx, req.(*DecideLoanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoanService_DisburseLoan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisburseLoanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoanServiceServer).DisburseLoan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoanService_DisburseLoan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoanServiceServer).DisburseLoan(ctx, req.(*DisburseLoanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoanService_RepayLoan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepayLoanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoanServiceServer).RepayLoan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoanService_RepayLoan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoanServiceServer).RepayLoan(ctx, req.(*RepayLoanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoanService_GetPayoff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPayoffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoanServiceServer).GetPayoff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoanService_GetPayoff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoanServiceServer).GetPayoff(ctx, req.(*GetPayoffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LoanService_SetRepaymentAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRepaymentAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoanServiceServer).SetRepaymentAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LoanService_SetRepaymentAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoanServiceServer).SetRepaymentAccount(ctx, req.(*SetRepaymentAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LoanService_ServiceDesc is the grpc.ServiceDesc for LoanService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LoanService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.v1.LoanService",
+	HandlerType: (*LoanServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ApplyLoan",
+			Handler:    _LoanService_ApplyLoan_Handler,
+		},
+		{
+			MethodName: "GetLoan",
+			Handler:    _LoanService_GetLoan_Handler,
+		},
+		{
+			MethodName: "DecideLoan",
+			Handler:    _LoanService_DecideLoan_Handler,
+		},
+		{
+			MethodName: "DisburseLoan",
+			Handler:    _LoanService_DisburseLoan_Handler,
+		},
+		{
+			MethodName: "RepayLoan",
+			Handler:    _LoanService_RepayLoan_Handler,
+		},
+		{
+			MethodName: "GetPayoff",
+			Handler:    _LoanService_GetPayoff_Handler,
+		},
+		{
+			MethodName: "SetRepaymentAccount",
+			Handler:    _LoanService_SetRepaymentAccount_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bank/v1/loans.proto",
+}
@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: bank/v1/transactions.proto
+
+package bankv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_bank_v1_transactions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_transactions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_transactions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListTransactionsRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+type WatchTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     uint64                 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AfterId       uint64                 `protobuf:"varint,2,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"` // 0 replays the whole history first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTransactionsRequest) Reset() {
+	*x = WatchTransactionsRequest{}
+	mi := &file_bank_v1_transactions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTransactionsRequest) ProtoMessage() {}
+
+func (x *WatchTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_transactions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*WatchTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_bank_v1_transactions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchTransactionsRequest) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *WatchTransactionsRequest) GetAfterId() uint64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+var File_bank_v1_transactions_proto protoreflect.FileDescriptor
+
+const file_bank_v1_transactions_proto_rawDesc = "" +
+	"\n" +
+	"\x1abank/v1/transactions.proto\x12\abank.v1\x1a\x13bank/v1/types.proto\"8\n" +
+	"\x17ListTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\"T\n" +
+	"\x18WatchTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x04R\taccountId\x12\x19\n" +
+	"\bafter_id\x18\x02 \x01(\x04R\aafterId2\xb2\x01\n" +
+	"\x12TransactionService\x12L\n" +
+	"\x10ListTransactions\x12 .bank.v1.ListTransactionsRequest\x1a\x14.bank.v1.Transaction0\x01\x12N\n" +
+	"\x11WatchTransactions\x12!.bank.v1.WatchTransactionsRequest\x1a\x14.bank.v1.Transaction0\x01BAZ?github.com/bhushangupta162/bank_management/proto/bank/v1;bankv1b\x06proto3"
+
+var (
+	file_bank_v1_transactions_proto_rawDescOnce sync.Once
+	file_bank_v1_transactions_proto_rawDescData []byte
+)
+
+func file_bank_v1_transactions_proto_rawDescGZIP() []byte {
+	file_bank_v1_transactions_proto_rawDescOnce.Do(func() {
+		file_bank_v1_transactions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bank_v1_transactions_proto_rawDesc), len(file_bank_v1_transactions_proto_rawDesc)))
+	})
+	return file_bank_v1_transactions_proto_rawDescData
+}
+
+var file_bank_v1_transactions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_bank_v1_transactions_proto_goTypes = []any{
+	(*ListTransactionsRequest)(nil),  // 0: bank.v1.ListTransactionsRequest
+	(*WatchTransactionsRequest)(nil), // 1: bank.v1.WatchTransactionsRequest
+	(*Transaction)(nil),              // 2: bank.v1.Transaction
+}
+var file_bank_v1_transactions_proto_depIdxs = []int32{
+	0, // 0: bank.v1.TransactionService.ListTransactions:input_type -> bank.v1.ListTransactionsRequest
+	1, // 1: bank.v1.TransactionService.WatchTransactions:input_type -> bank.v1.WatchTransactionsRequest
+	2, // 2: bank.v1.TransactionService.ListTransactions:output_type -> bank.v1.Transaction
+	2, // 3: bank.v1.TransactionService.WatchTransactions:output_type -> bank.v1.Transaction
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_bank_v1_transactions_proto_init() }
+func file_bank_v1_transactions_proto_init() {
+	if File_bank_v1_transactions_proto != nil {
+		return
+	}
+	file_bank_v1_types_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bank_v1_transactions_proto_rawDesc), len(file_bank_v1_transactions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bank_v1_transactions_proto_goTypes,
+		DependencyIndexes: file_bank_v1_transactions_proto_depIdxs,
+		MessageInfos:      file_bank_v1_transactions_proto_msgTypes,
+	}.Build()
+	File_bank_v1_transactions_proto = out.File
+	file_bank_v1_transactions_proto_goTypes = nil
+	file_bank_v1_transactions_proto_depIdxs = nil
+}
@@ -0,0 +1,773 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: bank/v1/types.proto
+
+package bankv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Account mirrors models.Account.
+type Account struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId           uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccountNumber    string                 `protobuf:"bytes,3,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	Iban             string                 `protobuf:"bytes,4,opt,name=iban,proto3" json:"iban,omitempty"` // Empty unless IBANs are enabled
+	Product          string                 `protobuf:"bytes,5,opt,name=product,proto3" json:"product,omitempty"`
+	Currency         string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217
+	Balance          float64                `protobuf:"fixed64,7,opt,name=balance,proto3" json:"balance,omitempty"`
+	Status           string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // active, frozen, dormant, closed
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ClosedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	OverdraftLimit   float64                `protobuf:"fixed64,12,opt,name=overdraft_limit,json=overdraftLimit,proto3" json:"overdraft_limit,omitempty"`       // How far below zero the balance may go
+	OverdraftRate    float64                `protobuf:"fixed64,13,opt,name=overdraft_rate,json=overdraftRate,proto3" json:"overdraft_rate,omitempty"`          // Annual interest in percent on a negative balance
+	OverdraftFee     float64                `protobuf:"fixed64,14,opt,name=overdraft_fee,json=overdraftFee,proto3" json:"overdraft_fee,omitempty"`             // Charged when a debit takes the balance below zero
+	AvailableBalance float64                `protobuf:"fixed64,15,opt,name=available_balance,json=availableBalance,proto3" json:"available_balance,omitempty"` // Ledger balance plus overdraft limit, less active holds
+	HeldAmount       float64                `protobuf:"fixed64,16,opt,name=held_amount,json=heldAmount,proto3" json:"held_amount,omitempty"`                   // Sum of the active holds
+	LedgerBalance    float64                `protobuf:"fixed64,17,opt,name=ledger_balance,json=ledgerBalance,proto3" json:"ledger_balance,omitempty"`          // Same as balance: the sum of posted transactions
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_bank_v1_types_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_types_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_bank_v1_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Account) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Account) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Account) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *Account) GetIban() string {
+	if x != nil {
+		return x.Iban
+	}
+	return ""
+}
+
+func (x *Account) GetProduct() string {
+	if x != nil {
+		return x.Product
+	}
+	return ""
+}
+
+func (x *Account) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Account) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Account) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Account) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Account) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Account) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Account) GetOverdraftLimit() float64 {
+	if x != nil {
+		return x.OverdraftLimit
+	}
+	return 0
+}
+
+func (x *Account) GetOverdraftRate() float64 {
+	if x != nil {
+		return x.OverdraftRate
+	}
+	return 0
+}
+
+func (x *Account) GetOverdraftFee() float64 {
+	if x != nil {
+		return x.OverdraftFee
+	}
+	return 0
+}
+
+func (x *Account) GetAvailableBalance() float64 {
+	if x != nil {
+		return x.AvailableBalance
+	}
+	return 0
+}
+
+func (x *Account) GetHeldAmount() float64 {
+	if x != nil {
+		return x.HeldAmount
+	}
+	return 0
+}
+
+func (x *Account) GetLedgerBalance() float64 {
+	if x != nil {
+		return x.LedgerBalance
+	}
+	return 0
+}
+
+// Transaction mirrors models.Transaction.
+type Transaction struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId       uint64                 `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TransactionType string                 `protobuf:"bytes,3,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"` // deposit, withdrawal, transfer-out, transfer-in
+	Amount          float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Description     string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	BalanceAfter    float64                `protobuf:"fixed64,7,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"` // Account balance right after this transaction
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_bank_v1_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_bank_v1_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Transaction) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetAccountId() uint64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *Transaction) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Transaction) GetBalanceAfter() float64 {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return 0
+}
+
+// Loan mirrors models.Loan.
+type Loan struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId               uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Principal            float64                `protobuf:"fixed64,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	InterestRate         float64                `protobuf:"fixed64,4,opt,name=interest_rate,json=interestRate,proto3" json:"interest_rate,omitempty"` // Annual rate in percent, priced by underwriting
+	TermMonths           int32                  `protobuf:"varint,5,opt,name=term_months,json=termMonths,proto3" json:"term_months,omitempty"`
+	Status               string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // pending, approved, rejected, cancelled, active, delinquent, defaulted, written_off, closed
+	OutstandingBalance   float64                `protobuf:"fixed64,7,opt,name=outstanding_balance,json=outstandingBalance,proto3" json:"outstanding_balance,omitempty"`
+	DecidedById          uint64                 `protobuf:"varint,8,opt,name=decided_by_id,json=decidedById,proto3" json:"decided_by_id,omitempty"` // 0 until a staff member decided
+	DecidedAt            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UnderwritingDecision string                 `protobuf:"bytes,12,opt,name=underwriting_decision,json=underwritingDecision,proto3" json:"underwriting_decision,omitempty"` // approve, refer or decline
+	CreditScore          int32                  `protobuf:"varint,13,opt,name=credit_score,json=creditScore,proto3" json:"credit_score,omitempty"`
+	ReasonCodes          []string               `protobuf:"bytes,14,rep,name=reason_codes,json=reasonCodes,proto3" json:"reason_codes,omitempty"`
+	ProductId            uint64                 `protobuf:"varint,15,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // 0 for loans taken out before the product catalog
+	ProductCode          string                 `protobuf:"bytes,16,opt,name=product_code,json=productCode,proto3" json:"product_code,omitempty"`
+	OriginationFee       float64                `protobuf:"fixed64,17,opt,name=origination_fee,json=originationFee,proto3" json:"origination_fee,omitempty"`
+	DisbursedAt          *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=disbursed_at,json=disbursedAt,proto3" json:"disbursed_at,omitempty"`
+	InstallmentAmount    float64                `protobuf:"fixed64,19,opt,name=installment_amount,json=installmentAmount,proto3" json:"installment_amount,omitempty"`
+	DaysPastDue          int32                  `protobuf:"varint,20,opt,name=days_past_due,json=daysPastDue,proto3" json:"days_past_due,omitempty"`
+	DelinquencyBucket    string                 `protobuf:"bytes,21,opt,name=delinquency_bucket,json=delinquencyBucket,proto3" json:"delinquency_bucket,omitempty"`       // current, 1-29, 30-59, 60-89 or 90+
+	FeesOutstanding      float64                `protobuf:"fixed64,22,opt,name=fees_outstanding,json=feesOutstanding,proto3" json:"fees_outstanding,omitempty"`           // Late fees charged and not yet paid
+	RepaymentAccountId   uint64                 `protobuf:"varint,23,opt,name=repayment_account_id,json=repaymentAccountId,proto3" json:"repayment_account_id,omitempty"` // 0 when installments are not collected by direct debit
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Loan) Reset() {
+	*x = Loan{}
+	mi := &file_bank_v1_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Loan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Loan) ProtoMessage() {}
+
+func (x *Loan) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Loan.ProtoReflect.Descriptor instead.
+func (*Loan) Descriptor() ([]byte, []int) {
+	return file_bank_v1_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Loan) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Loan) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Loan) GetPrincipal() float64 {
+	if x != nil {
+		return x.Principal
+	}
+	return 0
+}
+
+func (x *Loan) GetInterestRate() float64 {
+	if x != nil {
+		return x.InterestRate
+	}
+	return 0
+}
+
+func (x *Loan) GetTermMonths() int32 {
+	if x != nil {
+		return x.TermMonths
+	}
+	return 0
+}
+
+func (x *Loan) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Loan) GetOutstandingBalance() float64 {
+	if x != nil {
+		return x.OutstandingBalance
+	}
+	return 0
+}
+
+func (x *Loan) GetDecidedById() uint64 {
+	if x != nil {
+		return x.DecidedById
+	}
+	return 0
+}
+
+func (x *Loan) GetDecidedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return nil
+}
+
+func (x *Loan) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Loan) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Loan) GetUnderwritingDecision() string {
+	if x != nil {
+		return x.UnderwritingDecision
+	}
+	return ""
+}
+
+func (x *Loan) GetCreditScore() int32 {
+	if x != nil {
+		return x.CreditScore
+	}
+	return 0
+}
+
+func (x *Loan) GetReasonCodes() []string {
+	if x != nil {
+		return x.ReasonCodes
+	}
+	return nil
+}
+
+func (x *Loan) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *Loan) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *Loan) GetOriginationFee() float64 {
+	if x != nil {
+		return x.OriginationFee
+	}
+	return 0
+}
+
+func (x *Loan) GetDisbursedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisbursedAt
+	}
+	return nil
+}
+
+func (x *Loan) GetInstallmentAmount() float64 {
+	if x != nil {
+		return x.InstallmentAmount
+	}
+	return 0
+}
+
+func (x *Loan) GetDaysPastDue() int32 {
+	if x != nil {
+		return x.DaysPastDue
+	}
+	return 0
+}
+
+func (x *Loan) GetDelinquencyBucket() string {
+	if x != nil {
+		return x.DelinquencyBucket
+	}
+	return ""
+}
+
+func (x *Loan) GetFeesOutstanding() float64 {
+	if x != nil {
+		return x.FeesOutstanding
+	}
+	return 0
+}
+
+func (x *Loan) GetRepaymentAccountId() uint64 {
+	if x != nil {
+		return x.RepaymentAccountId
+	}
+	return 0
+}
+
+// PendingAction mirrors models.PendingAction: an operation waiting for a
+// second user (the checker) to approve it under /api/v1/pending-actions.
+type PendingAction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // transaction.reverse, transfer.large, loan.decide, account.adjust
+	EntityType    string                 `protobuf:"bytes,3,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId      uint64                 `protobuf:"varint,4,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"` // JSON
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // pending, executed, rejected, failed, expired
+	MakerId       uint64                 `protobuf:"varint,8,opt,name=maker_id,json=makerId,proto3" json:"maker_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingAction) Reset() {
+	*x = PendingAction{}
+	mi := &file_bank_v1_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingAction) ProtoMessage() {}
+
+func (x *PendingAction) ProtoReflect() protoreflect.Message {
+	mi := &file_bank_v1_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingAction.ProtoReflect.Descriptor instead.
+func (*PendingAction) Descriptor() ([]byte, []int) {
+	return file_bank_v1_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PendingAction) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PendingAction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PendingAction) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *PendingAction) GetEntityId() uint64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *PendingAction) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *PendingAction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PendingAction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PendingAction) GetMakerId() uint64 {
+	if x != nil {
+		return x.MakerId
+	}
+	return 0
+}
+
+func (x *PendingAction) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PendingAction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_bank_v1_types_proto protoreflect.FileDescriptor
+
+const file_bank_v1_types_proto_rawDesc = "" +
+	"\n" +
+	"\x13bank/v1/types.proto\x12\abank.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xee\x04\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12%\n" +
+	"\x0eaccount_number\x18\x03 \x01(\tR\raccountNumber\x12\x12\n" +
+	"\x04iban\x18\x04 \x01(\tR\x04iban\x12\x18\n" +
+	"\aproduct\x18\x05 \x01(\tR\aproduct\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x18\n" +
+	"\abalance\x18\a \x01(\x01R\abalance\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
+	"\tclosed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12'\n" +
+	"\x0foverdraft_limit\x18\f \x01(\x01R\x0eoverdraftLimit\x12%\n" +
+	"\x0eoverdraft_rate\x18\r \x01(\x01R\roverdraftRate\x12#\n" +
+	"\roverdraft_fee\x18\x0e \x01(\x01R\foverdraftFee\x12+\n" +
+	"\x11available_balance\x18\x0f \x01(\x01R\x10availableBalance\x12\x1f\n" +
+	"\vheld_amount\x18\x10 \x01(\x01R\n" +
+	"heldAmount\x12%\n" +
+	"\x0eledger_balance\x18\x11 \x01(\x01R\rledgerBalance\"\x81\x02\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\x04R\taccountId\x12)\n" +
+	"\x10transaction_type\x18\x03 \x01(\tR\x0ftransactionType\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rbalance_after\x18\a \x01(\x01R\fbalanceAfter\"\xb5\a\n" +
+	"\x04Loan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x1c\n" +
+	"\tprincipal\x18\x03 \x01(\x01R\tprincipal\x12#\n" +
+	"\rinterest_rate\x18\x04 \x01(\x01R\finterestRate\x12\x1f\n" +
+	"\vterm_months\x18\x05 \x01(\x05R\n" +
+	"termMonths\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12/\n" +
+	"\x13outstanding_balance\x18\a \x01(\x01R\x12outstandingBalance\x12\"\n" +
+	"\rdecided_by_id\x18\b \x01(\x04R\vdecidedById\x129\n" +
+	"\n" +
+	"decided_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdecidedAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x123\n" +
+	"\x15underwriting_decision\x18\f \x01(\tR\x14underwritingDecision\x12!\n" +
+	"\fcredit_score\x18\r \x01(\x05R\vcreditScore\x12!\n" +
+	"\freason_codes\x18\x0e \x03(\tR\vreasonCodes\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x0f \x01(\x04R\tproductId\x12!\n" +
+	"\fproduct_code\x18\x10 \x01(\tR\vproductCode\x12'\n" +
+	"\x0forigination_fee\x18\x11 \x01(\x01R\x0eoriginationFee\x12=\n" +
+	"\fdisbursed_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\vdisbursedAt\x12-\n" +
+	"\x12installment_amount\x18\x13 \x01(\x01R\x11installmentAmount\x12\"\n" +
+	"\rdays_past_due\x18\x14 \x01(\x05R\vdaysPastDue\x12-\n" +
+	"\x12delinquency_bucket\x18\x15 \x01(\tR\x11delinquencyBucket\x12)\n" +
+	"\x10fees_outstanding\x18\x16 \x01(\x01R\x0ffeesOutstanding\x120\n" +
+	"\x14repayment_account_id\x18\x17 \x01(\x04R\x12repaymentAccountId\"\xcc\x02\n" +
+	"\rPendingAction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1f\n" +
+	"\ventity_type\x18\x03 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x04 \x01(\x04R\bentityId\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x19\n" +
+	"\bmaker_id\x18\b \x01(\x04R\amakerId\x129\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtBAZ?github.com/bhushangupta162/bank_management/proto/bank/v1;bankv1b\x06proto3"
+
+var (
+	file_bank_v1_types_proto_rawDescOnce sync.Once
+	file_bank_v1_types_proto_rawDescData []byte
+)
+
+func file_bank_v1_types_proto_rawDescGZIP() []byte {
+	file_bank_v1_types_proto_rawDescOnce.Do(func() {
+		file_bank_v1_types_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bank_v1_types_proto_rawDesc), len(file_bank_v1_types_proto_rawDesc)))
+	})
+	return file_bank_v1_types_proto_rawDescData
+}
+
+var file_bank_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_bank_v1_types_proto_goTypes = []any{
+	(*Account)(nil),               // 0: bank.v1.Account
+	(*Transaction)(nil),           // 1: bank.v1.Transaction
+	(*Loan)(nil),                  // 2: bank.v1.Loan
+	(*PendingAction)(nil),         // 3: bank.v1.PendingAction
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_bank_v1_types_proto_depIdxs = []int32{
+	4,  // 0: bank.v1.Account.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 1: bank.v1.Account.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 2: bank.v1.Account.closed_at:type_name -> google.protobuf.Timestamp
+	4,  // 3: bank.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 4: bank.v1.Loan.decided_at:type_name -> google.protobuf.Timestamp
+	4,  // 5: bank.v1.Loan.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 6: bank.v1.Loan.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 7: bank.v1.Loan.disbursed_at:type_name -> google.protobuf.Timestamp
+	4,  // 8: bank.v1.PendingAction.expires_at:type_name -> google.protobuf.Timestamp
+	4,  // 9: bank.v1.PendingAction.created_at:type_name -> google.protobuf.Timestamp
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_bank_v1_types_proto_init() }
+func file_bank_v1_types_proto_init() {
+	if File_bank_v1_types_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bank_v1_types_proto_rawDesc), len(file_bank_v1_types_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_bank_v1_types_proto_goTypes,
+		DependencyIndexes: file_bank_v1_types_proto_depIdxs,
+		MessageInfos:      file_bank_v1_types_proto_msgTypes,
+	}.Build()
+	File_bank_v1_types_proto = out.File
+	file_bank_v1_types_proto_goTypes = nil
+	file_bank_v1_types_proto_depIdxs = nil
+}
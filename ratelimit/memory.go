@@ -0,0 +1,59 @@
+// ratelimit/memory.go
+package ratelimit
+
+import (
+    "context"
+    "sync"
+    "time"
+
+    "github.com/bhushangupta162/bank_management/config"
+)
+
+// sweepInterval is how often idle buckets are dropped from memory.
+const sweepInterval = time.Minute
+
+// MemoryStore keeps buckets in process memory. Limits are per instance.
+type MemoryStore struct {
+    mu        sync.Mutex
+    buckets   map[string]*memoryBucket
+    lastSweep time.Time
+}
+
+type memoryBucket struct {
+    bucket
+    rule config.RateLimitRule
+}
+
+// NewMemoryStore returns an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+    return &MemoryStore{buckets: map[string]*memoryBucket{}}
+}
+
+// Take spends a token from the key's bucket.
+func (s *MemoryStore) Take(_ context.Context, key string, rule config.RateLimitRule, now time.Time) (Result, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    s.sweep(now)
+
+    b, ok := s.buckets[key]
+    if !ok {
+        b = &memoryBucket{bucket: newBucket(rule, now), rule: rule}
+        s.buckets[key] = b
+    }
+    return b.take(rule, now), nil
+}
+
+// sweep drops buckets that have refilled completely; a new request would
+// recreate them in the same state. Caller holds the lock.
+func (s *MemoryStore) sweep(now time.Time) {
+    if now.Sub(s.lastSweep) < sweepInterval {
+        return
+    }
+    s.lastSweep = now
+    for key, b := range s.buckets {
+        if b.full(b.rule, now) {
+            delete(s.buckets, key)
+        }
+    }
+}
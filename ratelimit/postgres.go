@@ -0,0 +1,49 @@
+// ratelimit/postgres.go
+package ratelimit
+
+import (
+    "context"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// PostgresStore keeps buckets in the rate_limit_buckets table so every API
+// instance shares the same limits. The row lock makes Take atomic per key.
+type PostgresStore struct {
+    DB *gorm.DB
+}
+
+// NewPostgresStore returns a store backed by db.
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+    return &PostgresStore{DB: db}
+}
+
+// Take spends a token from the key's bucket.
+func (s *PostgresStore) Take(ctx context.Context, key string, rule config.RateLimitRule, now time.Time) (Result, error) {
+    var res Result
+    err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        fresh := newBucket(rule, now)
+        row := models.RateLimitBucket{Key: key, Tokens: fresh.tokens, LastRefill: now}
+        if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+            return err
+        }
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&row, "key = ?", key).Error; err != nil {
+            return err
+        }
+
+        b := bucket{tokens: row.Tokens, last: row.LastRefill}
+        res = b.take(rule, now)
+        return tx.Model(&row).Updates(map[string]interface{}{"tokens": b.tokens, "last_refill": b.last}).Error
+    })
+    return res, err
+}
+
+// Prune deletes buckets that have been idle for longer than maxIdle.
+func (s *PostgresStore) Prune(ctx context.Context, maxIdle time.Duration) error {
+    return s.DB.WithContext(ctx).Where("last_refill < ?", time.Now().Add(-maxIdle)).Delete(&models.RateLimitBucket{}).Error
+}
@@ -0,0 +1,75 @@
+// ratelimit/ratelimit.go
+package ratelimit
+
+import (
+    "context"
+    "math"
+    "time"
+
+    "github.com/bhushangupta162/bank_management/config"
+)
+
+// Result is the outcome of taking one token.
+type Result struct {
+    Allowed    bool
+    Limit      int           // Bucket size
+    Remaining  int           // Whole tokens left after this request
+    RetryAfter time.Duration // Until the next token, when denied
+    Reset      time.Duration // Until the bucket is full again
+}
+
+// Store keeps bucket state. MemoryStore works for a single instance; a
+// distributed store (Postgres, Redis, ...) lets several instances share
+// limits. Take must be atomic per key.
+type Store interface {
+    Take(ctx context.Context, key string, rule config.RateLimitRule, now time.Time) (Result, error)
+}
+
+// bucket is the token bucket state shared by all stores.
+type bucket struct {
+    tokens float64
+    last   time.Time
+}
+
+// ratePerSecond is how fast tokens come back.
+func ratePerSecond(rule config.RateLimitRule) float64 {
+    return float64(rule.Requests) / rule.Period.Seconds()
+}
+
+// newBucket starts full.
+func newBucket(rule config.RateLimitRule, now time.Time) bucket {
+    return bucket{tokens: float64(rule.Burst), last: now}
+}
+
+// take refills the bucket for the time since the last request and spends one
+// token if there is one.
+func (b *bucket) take(rule config.RateLimitRule, now time.Time) Result {
+    rate := ratePerSecond(rule)
+    burst := float64(rule.Burst)
+
+    if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+        b.tokens = math.Min(burst, b.tokens+elapsed*rate)
+    }
+    b.last = now
+
+    res := Result{Limit: rule.Burst}
+    if b.tokens >= 1 {
+        b.tokens--
+        res.Allowed = true
+    } else {
+        res.RetryAfter = seconds((1 - b.tokens) / rate)
+    }
+    res.Remaining = int(math.Floor(b.tokens))
+    res.Reset = seconds((burst - b.tokens) / rate)
+    return res
+}
+
+// full reports whether the bucket would be full by now, i.e. it carries no
+// state worth keeping.
+func (b *bucket) full(rule config.RateLimitRule, now time.Time) bool {
+    return b.tokens+now.Sub(b.last).Seconds()*ratePerSecond(rule) >= float64(rule.Burst)
+}
+
+func seconds(s float64) time.Duration {
+    return time.Duration(s * float64(time.Second))
+}
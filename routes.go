@@ -0,0 +1,56 @@
+// routes.go
+package main
+
+import (
+    "log/slog"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/apiversion"
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/docs"
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/mailer"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/ratelimit"
+    "github.com/bhushangupta162/bank_management/stream"
+    "github.com/bhushangupta162/bank_management/tracing"
+)
+
+// registerRoutes installs the middleware and every HTTP route. Handlers only
+// use db when a request comes in, so tests can build the router without a database.
+// API routes live under /api/v1; the pre-versioning paths are served as deprecated
+// aliases until the sunset date.
+func registerRoutes(router *gin.Engine, db *gorm.DB, mail mailer.Mailer, cfg config.Config, logger *slog.Logger, limiter *ratelimit.Limiter, broker stream.Broker) {
+    // Tracing, request IDs (X-Request-ID) and request-scoped loggers come first so
+    // later middleware and handlers can use them. Handlers pass the request context
+    // to GORM (db.WithContext) so queries show up as child spans.
+    router.Use(tracing.Middleware(cfg.Tracing.ServiceName))
+    router.Use(logging.RequestID(logger), logging.AccessLog(), logging.Recovery())
+
+    // Prometheus metrics: per-route latency and status codes, DB pool and business counters.
+    router.Use(metrics.Middleware())
+    router.GET("/metrics", metrics.Handler(cfg.Metrics.Token))
+
+    // OpenAPI spec at /openapi.json and a browsable version at /docs.
+    docs.Register(router)
+
+    // Rate limits: a default bucket for every route plus stricter ones for the
+    // auth and money groups of the API. Rejected requests stop here and are not audited.
+    router.Use(limiter.Middleware("default", cfg.RateLimit.Default, ratelimit.ByUserOrIP))
+    authLimit := limiter.Middleware("auth", cfg.RateLimit.Auth, ratelimit.ByIP)
+    moneyLimit := limiter.Middleware("money", cfg.RateLimit.Money, ratelimit.ByUserOrIP)
+
+    // Record every state-changing request in the hash-chained audit log.
+    router.Use(audit.Middleware(db))
+
+    // Versioned API, plus the old unprefixed paths as deprecated aliases
+    // (API_LEGACY_ROUTES=false turns them off).
+    api := apiRoutes{db: db, mail: mail, cfg: cfg, authLimit: authLimit, moneyLimit: moneyLimit, broker: broker}
+    api.registerV1(router.Group(apiversion.V1))
+    if cfg.API.LegacyRoutes {
+        api.registerV1(router.Group("", apiversion.Deprecated(cfg.API.DeprecatedAt, cfg.API.Sunset, apiversion.V1)))
+    }
+}
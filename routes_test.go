@@ -0,0 +1,118 @@
+// routes_test.go
+package main
+
+import (
+    "encoding/json"
+    "io"
+    "log/slog"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/docs"
+    "github.com/bhushangupta162/bank_management/mailer"
+    "github.com/bhushangupta162/bank_management/ratelimit"
+    "github.com/bhushangupta162/bank_management/stream"
+)
+
+// testRouter builds the real router without a database; handlers only touch
+// db when a request is served.
+func testRouter(t *testing.T) *gin.Engine {
+    t.Helper()
+    gin.SetMode(gin.TestMode)
+    cfg := config.Load()
+    mail, err := mailer.New(cfg.Mail)
+    if err != nil {
+        t.Fatalf("mailer: %v", err)
+    }
+    router := gin.New()
+    logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+    registerRoutes(router, nil, mail, cfg, logger, ratelimit.New(ratelimit.NewMemoryStore(), false), stream.NewMemoryBroker(1))
+    return router
+}
+
+// openAPIPath turns a gin path ("/accounts/:id") into its OpenAPI form ("/accounts/{id}").
+func openAPIPath(path string) string {
+    parts := strings.Split(path, "/")
+    for i, p := range parts {
+        if strings.HasPrefix(p, ":") || strings.HasPrefix(p, "*") {
+            parts[i] = "{" + p[1:] + "}"
+        }
+    }
+    return strings.Join(parts, "/")
+}
+
+func TestEveryRouteIsInOpenAPISpec(t *testing.T) {
+    var spec struct {
+        OpenAPI string                            `json:"openapi"`
+        Paths   map[string]map[string]interface{} `json:"paths"`
+    }
+    if err := json.Unmarshal(docs.Spec, &spec); err != nil {
+        t.Fatalf("openapi.json is not valid JSON: %v", err)
+    }
+    if !strings.HasPrefix(spec.OpenAPI, "3.") {
+        t.Fatalf("openapi version = %q, want 3.x", spec.OpenAPI)
+    }
+
+    routes := testRouter(t).Routes()
+    if len(routes) == 0 {
+        t.Fatal("no routes registered")
+    }
+    registered := make(map[string]bool)
+    for _, r := range routes {
+        path := openAPIPath(r.Path)
+        method := strings.ToLower(r.Method)
+        registered[method+" "+path] = true
+        if _, ok := spec.Paths[path][method]; !ok {
+            t.Errorf("%s %s is not documented in docs/openapi.json", r.Method, r.Path)
+        }
+    }
+
+    // The spec should not promise routes that do not exist either.
+    for path, ops := range spec.Paths {
+        for method := range ops {
+            if method == "parameters" {
+                continue
+            }
+            if !registered[method+" "+path] {
+                t.Errorf("docs/openapi.json documents %s %s, which is not registered", strings.ToUpper(method), path)
+            }
+        }
+    }
+}
+
+func TestLegacyRoutesAreDeprecated(t *testing.T) {
+    router := testRouter(t)
+
+    get := func(path string) *httptest.ResponseRecorder {
+        w := httptest.NewRecorder()
+        router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+        return w
+    }
+
+    v1 := get("/api/v1/iban/validate?iban=DE89370400440532013000")
+    if v1.Code != http.StatusOK {
+        t.Fatalf("v1 status = %d, want 200", v1.Code)
+    }
+    if v1.Header().Get("Deprecation") != "" || v1.Header().Get("Sunset") != "" {
+        t.Errorf("v1 route sent deprecation headers: %v", v1.Header())
+    }
+
+    legacy := get("/iban/validate?iban=DE89370400440532013000")
+    if legacy.Code != http.StatusOK {
+        t.Fatalf("legacy status = %d, want 200", legacy.Code)
+    }
+    if !strings.HasPrefix(legacy.Header().Get("Deprecation"), "@") {
+        t.Errorf("Deprecation = %q, want @<unix time>", legacy.Header().Get("Deprecation"))
+    }
+    if _, err := http.ParseTime(legacy.Header().Get("Sunset")); err != nil {
+        t.Errorf("Sunset = %q is not an HTTP date: %v", legacy.Header().Get("Sunset"), err)
+    }
+    if want := `</api/v1/iban/validate>; rel="successor-version"`; legacy.Header().Get("Link") != want {
+        t.Errorf("Link = %q, want %q", legacy.Header().Get("Link"), want)
+    }
+}
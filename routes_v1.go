@@ -0,0 +1,144 @@
+// routes_v1.go
+package main
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/handlers"
+    "github.com/bhushangupta162/bank_management/mailer"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/stream"
+)
+
+// apiRoutes holds what the versioned route registrations need. A future
+// registerV2 (in routes_v2.go, with handlers in handlers/v2) takes the same
+// dependencies and calls into the same services as v1.
+type apiRoutes struct {
+    db         *gorm.DB
+    mail       mailer.Mailer
+    cfg        config.Config
+    authLimit  gin.HandlerFunc // Stricter rate limit for signup, login and password reset
+    moneyLimit gin.HandlerFunc // Stricter rate limit for money movement
+    broker     stream.Broker   // Live transaction events for account streams
+}
+
+// registerV1 adds the v1 API routes to r. It runs once for /api/v1 and once
+// more for the deprecated unprefixed aliases.
+func (a apiRoutes) registerV1(r *gin.RouterGroup) {
+    // Define the authentication routes (rate limited per IP).
+    authRoutes := r.Group("", a.authLimit)
+    authRoutes.POST("/signup", handlers.SignUpHandler(a.db, a.mail, a.cfg.Email))
+    authRoutes.POST("/login", handlers.LoginHandler(a.db, a.cfg.MFA))
+    authRoutes.POST("/login/mfa", handlers.MFALoginHandler(a.db)) // Second step when 2FA is enabled
+
+    // Email verification and password reset
+    r.GET("/verify-email", handlers.VerifyEmailHandler(a.db)) // Link from the verification email
+    authRoutes.POST("/verify-email", handlers.VerifyEmailHandler(a.db))
+    authRoutes.POST("/resend-verification", AuthMiddleware(), handlers.ResendVerificationHandler(a.db, a.mail, a.cfg.Email))
+    authRoutes.POST("/forgot-password", handlers.ForgotPasswordHandler(a.db, a.mail, a.cfg.Email))
+    r.GET("/reset-password", handlers.ResetPasswordPageHandler()) // Link from the reset email
+    authRoutes.POST("/reset-password", handlers.ResetPasswordHandler(a.db))
+
+    // Two-factor authentication enrollment (requires an access token)
+    mfa := r.Group("/mfa", AuthMiddleware())
+    mfa.POST("/enroll", handlers.EnrollMFAHandler(a.db, a.cfg.MFA))
+    mfa.POST("/confirm", handlers.ConfirmMFAHandler(a.db, a.cfg.MFA))
+    mfa.POST("/recovery-codes", handlers.RegenerateRecoveryCodesHandler(a.db, a.cfg.MFA))
+    mfa.POST("/disable", handlers.DisableMFAHandler(a.db))
+
+    // Account routes
+    r.POST("/accounts", handlers.CreateAccountHandler(a.db, a.cfg.AccountNumbers)) // Create an account
+    r.GET("/accounts/:id", handlers.GetAccountHandler(a.db, a.cfg.AccountNumbers)) // ID, account number or IBAN
+    money := r.Group("", a.moneyLimit)                                             // Rate limited per user
+    money.POST("/accounts/:id/deposit", handlers.DepositHandler(a.db, a.cfg.Email))
+    money.POST("/accounts/:id/withdraw", handlers.WithdrawHandler(a.db, a.cfg.Email, a.cfg.Limits))
+    money.POST("/accounts/transfer", handlers.TransferHandler(a.db, a.cfg.MFA, a.cfg.Email, a.cfg.AccountNumbers, a.cfg.Limits, a.cfg.Approval)) // Large ones need approval
+    r.GET("/iban/validate", handlers.ValidateIBANHandler())
+    r.GET("/accounts/:id/transactions", handlers.GetTransactionsHandler(a.db))
+    r.GET("/accounts/:id/stream", AuthMiddleware(), handlers.StreamAccountHandler(a.db, a.broker, a.cfg.Stream)) // Server-Sent Events, owner or staff
+
+    // Reversals: staff reverse at once, customers' requests wait for a checker
+    r.POST("/transactions/:id/reverse", AuthMiddleware(), a.moneyLimit, handlers.ReverseTransactionHandler(a.db, a.cfg.Approval))
+
+    // Maker-checker approvals
+    pending := r.Group("/pending-actions", AuthMiddleware())
+    pending.GET("", handlers.ListPendingActionsHandler(a.db))   // Own proposals, or all for staff
+    pending.GET("/:id", handlers.GetPendingActionHandler(a.db)) // Maker or staff
+    checker := pending.Group("", RequireRole(a.db, models.RoleStaff, models.RoleAdmin))
+    checker.POST("/:id/approve", handlers.ApprovePendingActionHandler(a.db, a.cfg.Limits))
+    checker.POST("/:id/reject", handlers.RejectPendingActionHandler(a.db))
+
+    // Account lifecycle (freeze, dormant, close)
+    r.PATCH("/accounts/:id/status", AuthMiddleware(), RequireRole(a.db, models.RoleStaff, models.RoleAdmin), handlers.UpdateAccountStatusHandler(a.db))
+    r.POST("/accounts/:id/close", AuthMiddleware(), handlers.CloseAccountHandler(a.db, a.cfg.MFA, a.cfg.Email, a.cfg.Limits)) // Owner or staff
+    r.GET("/accounts/:id/status-history", AuthMiddleware(), handlers.GetAccountStatusHistoryHandler(a.db))
+    r.POST("/accounts/:id/adjustments", AuthMiddleware(), RequireRole(a.db, models.RoleStaff, models.RoleAdmin), handlers.AdjustBalanceHandler(a.db, a.cfg.Approval)) // Booked once a second staff member approves
+
+    // Overdraft facilities (staff)
+    r.PUT("/accounts/:id/overdraft", AuthMiddleware(), RequireRole(a.db, models.RoleStaff, models.RoleAdmin), handlers.SetOverdraftHandler(a.db, a.cfg.Overdraft))
+
+    // Holds (authorizations) on account funds (owner or staff)
+    r.GET("/accounts/:id/holds", AuthMiddleware(), handlers.ListHoldsHandler(a.db))
+    holds := r.Group("", AuthMiddleware(), a.moneyLimit)
+    holds.POST("/accounts/:id/holds", handlers.CreateHoldHandler(a.db, a.cfg.Email, a.cfg.Limits, a.cfg.Holds))
+    holds.POST("/holds/:id/capture", handlers.CaptureHoldHandler(a.db))
+    holds.POST("/holds/:id/release", handlers.ReleaseHoldHandler(a.db))
+
+    // Transaction limits
+    r.GET("/accounts/:id/limits", AuthMiddleware(), handlers.GetAccountLimitsHandler(a.db, a.cfg.Limits)) // Owner or staff
+    limits := r.Group("/limits", AuthMiddleware(), RequireRole(a.db, models.RoleStaff, models.RoleAdmin))
+    limits.GET("", handlers.ListLimitsHandler(a.db))
+    limits.PUT("/products/:product", handlers.SetProductLimitHandler(a.db))
+    limits.PUT("/users/:id", handlers.SetUserLimitHandler(a.db))
+
+    // Loan endpoints
+    r.GET("/loan-products", handlers.ListLoanProductsHandler(a.db))
+    r.GET("/loan-products/:id", handlers.GetLoanProductHandler(a.db))
+    products := r.Group("/loan-products", AuthMiddleware(), RequireRole(a.db, models.RoleStaff, models.RoleAdmin))
+    products.POST("", handlers.CreateLoanProductHandler(a.db))
+    products.PUT("/:id", handlers.UpdateLoanProductHandler(a.db))
+    r.POST("/loans/apply", handlers.ApplyLoanHandler(a.db, a.cfg.Underwriting))
+    r.PATCH("/loans/:id/status", AuthMiddleware(), RequireRole(a.db, models.RoleStaff, models.RoleAdmin), handlers.UpdateLoanStatusHandler(a.db, a.cfg.Approval)) // Proposes a status change for a second staff member
+    r.POST("/loans/:id/disburse", AuthMiddleware(), RequireRole(a.db, models.RoleStaff, models.RoleAdmin), handlers.DisburseLoanHandler(a.db))
+    r.POST("/loans/:id/repay", handlers.RepayLoanHandler(a.db, a.cfg.Loan))
+    r.GET("/loans/:id", AuthMiddleware(), handlers.GetLoanHandler(a.db))                  // Borrower or staff
+    r.GET("/loans/:id/schedule", AuthMiddleware(), handlers.GetLoanScheduleHandler(a.db)) // Borrower or staff
+    r.GET("/loans/:id/payoff", AuthMiddleware(), handlers.GetLoanPayoffHandler(a.db))     // ?date=, borrower or staff
+
+    // Direct debit of loan installments (borrower or staff)
+    directDebit := r.Group("/loans/:id", AuthMiddleware())
+    directDebit.PUT("/repayment-account", handlers.SetRepaymentAccountHandler(a.db))
+    directDebit.DELETE("/repayment-account", handlers.RemoveRepaymentAccountHandler(a.db))
+    directDebit.GET("/collections", handlers.ListLoanCollectionsHandler(a.db))
+
+    // Scheduled and recurring transfers (standing orders)
+    scheduled := r.Group("/scheduled-transfers", AuthMiddleware())
+    scheduled.POST("", a.moneyLimit, handlers.CreateScheduledTransferHandler(a.db, a.cfg.MFA, a.cfg.Email, a.cfg.AccountNumbers, a.cfg.Approval))
+    scheduled.GET("", handlers.ListScheduledTransfersHandler(a.db))
+    scheduled.GET("/:id", handlers.GetScheduledTransferHandler(a.db))
+    scheduled.POST("/:id/pause", handlers.PauseScheduledTransferHandler(a.db))
+    scheduled.POST("/:id/resume", handlers.ResumeScheduledTransferHandler(a.db))
+    scheduled.POST("/:id/cancel", handlers.CancelScheduledTransferHandler(a.db))
+
+    // Outbound webhooks (staff only)
+    webhooks := r.Group("/webhooks", AuthMiddleware(), RequireRole(a.db, models.RoleStaff, models.RoleAdmin))
+    webhooks.POST("", handlers.CreateWebhookHandler(a.db))
+    webhooks.GET("", handlers.ListWebhooksHandler(a.db))
+    webhooks.DELETE("/:id", handlers.DeleteWebhookHandler(a.db))
+    webhooks.GET("/deliveries", handlers.ListWebhookDeliveriesHandler(a.db)) // ?status=dead for the dead-letter list
+    webhooks.POST("/deliveries/:id/redeliver", handlers.RedeliverWebhookHandler(a.db))
+
+    // Audit log (admin only)
+    admin := r.Group("/admin", AuthMiddleware(), RequireRole(a.db, models.RoleAdmin))
+    admin.GET("/audit", handlers.ListAuditEntriesHandler(a.db)) // ?entity_type=&entity_id=&actor_id=&from=&to=
+    admin.GET("/audit/verify", handlers.VerifyAuditLogHandler(a.db))
+
+    // Example protected route.
+    r.GET("/protected", AuthMiddleware(), func(c *gin.Context) {
+        c.JSON(http.StatusOK, gin.H{"message": "You are authorized!"})
+    })
+}
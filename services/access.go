@@ -0,0 +1,70 @@
+// services/access.go
+package services
+
+import (
+    "errors"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// isStaff reports whether the user is staff or an admin.
+func isStaff(db *gorm.DB, userID uint) (bool, error) {
+    var user models.User
+    if err := db.Select("id", "role").First(&user, userID).Error; err != nil {
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return false, newError(ErrUnauthenticated, "User not found")
+        }
+        return false, err
+    }
+    return user.IsStaff(), nil
+}
+
+// requireAccountAccess allows the account's owner and staff, like
+// canAccessAccount in the REST handlers.
+func requireAccountAccess(db *gorm.DB, userID uint, account *models.Account) error {
+    if userID != 0 && userID == account.UserID {
+        return nil
+    }
+    staff, err := isStaff(db, userID)
+    if err != nil {
+        return err
+    }
+    if !staff {
+        return newError(ErrForbidden, "Not allowed to access this account")
+    }
+    return nil
+}
+
+// AccountForUser loads an account for its owner or for staff.
+func AccountForUser(db *gorm.DB, userID, accountID uint) (*models.Account, error) {
+    var account models.Account
+    if err := db.First(&account, accountID).Error; err != nil {
+        return nil, notFound(err, "Account not found")
+    }
+    if err := requireAccountAccess(db, userID, &account); err != nil {
+        return nil, err
+    }
+    return &account, nil
+}
+
+// LoanForUser loads a loan for its borrower or for staff. Other users get
+// not found, as on GET /loans/:id.
+func LoanForUser(db *gorm.DB, userID, loanID uint) (*models.Loan, error) {
+    var loan models.Loan
+    if err := db.First(&loan, loanID).Error; err != nil {
+        return nil, notFound(err, "Loan not found")
+    }
+    if loan.UserID == userID && userID != 0 {
+        return &loan, nil
+    }
+    staff, err := isStaff(db, userID)
+    if err != nil {
+        return nil, err
+    }
+    if !staff {
+        return nil, newError(ErrNotFound, "Loan not found")
+    }
+    return &loan, nil
+}
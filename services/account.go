@@ -0,0 +1,167 @@
+// services/account.go
+package services
+
+import (
+    "fmt"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// MovementResult is the outcome of a deposit or withdrawal: the account before
+// and after, and the logged transaction.
+type MovementResult struct {
+    Before       models.Account      `json:"-"`
+    Account      models.Account      `json:"account"`
+    Transaction  models.Transaction  `json:"transaction"`
+    OverdraftFee *models.Transaction `json:"overdraft_fee,omitempty"` // Charged when a withdrawal goes below zero
+}
+
+// CreateAccount opens an account with a fresh account number (and IBAN when enabled).
+// Empty product and currency default to "standard" and EUR.
+func CreateAccount(db *gorm.DB, numCfg config.AccountNumberConfig, userID uint, product, currency string) (*models.Account, error) {
+    if product == "" {
+        product = models.DefaultAccountProduct
+    }
+    if currency == "" {
+        currency = models.DefaultCurrency
+    }
+    if !models.IsValidCurrency(currency) {
+        return nil, newError(ErrInvalid, "currency must be a three-letter ISO 4217 code")
+    }
+    account := models.Account{
+        UserID:   userID,
+        Product:  product,
+        Currency: currency,
+        Balance:  0, // default balance
+    }
+    if err := AssignAccountIdentifiers(db, &account, numCfg); err != nil {
+        return nil, err
+    }
+    if err := db.Create(&account).Error; err != nil {
+        return nil, err
+    }
+    return &account, nil
+}
+
+// Deposit credits an account and logs a deposit transaction.
+func Deposit(db *gorm.DB, emailCfg config.EmailConfig, accountID uint, amount float64) (*MovementResult, error) {
+    var result MovementResult
+    if err := db.First(&result.Account, accountID).Error; err != nil {
+        return nil, notFound(err, "Account not found")
+    }
+    if err := RequireVerifiedEmail(db, emailCfg, result.Account.UserID); err != nil {
+        return nil, err
+    }
+    if amount <= 0 {
+        return nil, newError(ErrInvalid, "Amount must be positive")
+    }
+
+    err := db.Transaction(func(tx *gorm.DB) error {
+        account := &result.Account
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, accountID).Error; err != nil {
+            return notFound(err, "Account not found")
+        }
+        if !account.CanCredit() {
+            return newError(ErrForbidden, fmt.Sprintf("Account %d is %s and cannot receive funds", account.ID, account.Status))
+        }
+
+        result.Before = *account
+        account.Balance += amount
+        if err := tx.Save(account).Error; err != nil {
+            return err
+        }
+        result.Transaction = models.Transaction{
+            AccountID:       account.ID,
+            TransactionType: models.TransactionDeposit,
+            Amount:          amount,
+            Description:     "Deposit operation",
+            BalanceAfter:    account.Balance,
+        }
+        return tx.Create(&result.Transaction).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    metrics.MoneyMoved(metrics.TypeDeposit, result.Account.Currency, amount)
+    return &result, nil
+}
+
+// Withdraw debits an account and logs a withdrawal. The available balance and
+// outflow limits are checked in the same DB transaction as the debit.
+func Withdraw(db *gorm.DB, emailCfg config.EmailConfig, limits config.LimitsConfig, accountID uint, amount float64) (*MovementResult, error) {
+    var result MovementResult
+    if err := db.First(&result.Account, accountID).Error; err != nil {
+        return nil, notFound(err, "Account not found")
+    }
+    if err := RequireVerifiedEmail(db, emailCfg, result.Account.UserID); err != nil {
+        return nil, err
+    }
+    if amount <= 0 {
+        return nil, newError(ErrInvalid, "Amount must be positive")
+    }
+
+    err := db.Transaction(func(tx *gorm.DB) error {
+        // Lock the row so concurrent withdrawals see each other's effect on balance and limits
+        account := &result.Account
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, accountID).Error; err != nil {
+            return notFound(err, "Account not found")
+        }
+        if !account.CanDebit() {
+            return newError(ErrForbidden, fmt.Sprintf("Account %d is %s and cannot be debited", account.ID, account.Status))
+        }
+        if account.AvailableBalance() < amount {
+            return newError(ErrInvalid, "Insufficient balance")
+        }
+        if err := CheckOutflowLimits(tx, account, amount, false, limits); err != nil {
+            return err
+        }
+
+        result.Before = *account
+        account.Balance -= amount
+        if err := tx.Save(account).Error; err != nil {
+            return err
+        }
+        result.Transaction = models.Transaction{
+            AccountID:       account.ID,
+            TransactionType: models.TransactionWithdrawal,
+            Amount:          amount,
+            Description:     "Withdrawal operation",
+            BalanceAfter:    account.Balance,
+        }
+        if err := tx.Create(&result.Transaction).Error; err != nil {
+            return err
+        }
+        var err error
+        result.OverdraftFee, err = chargeOverdraftFee(tx, account, result.Before.Balance)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+    metrics.MoneyMoved(metrics.TypeWithdrawal, result.Account.Currency, amount)
+    if result.OverdraftFee != nil {
+        metrics.MoneyMoved(metrics.TypeOverdraft, result.Account.Currency, result.OverdraftFee.Amount)
+    }
+    return &result, nil
+}
+
+// RequireVerifiedEmail rejects money movement for users who have not verified
+// their email address, unless REQUIRE_VERIFIED_EMAIL is off.
+func RequireVerifiedEmail(db *gorm.DB, cfg config.EmailConfig, userID uint) error {
+    if !cfg.RequireVerified {
+        return nil
+    }
+    var user models.User
+    if err := db.Select("id", "email_verified_at").First(&user, userID).Error; err != nil {
+        return newError(ErrForbidden, "Account owner not found")
+    }
+    if user.EmailVerifiedAt == nil {
+        return newError(ErrForbidden, "Email address must be verified before moving money")
+    }
+    return nil
+}
@@ -0,0 +1,83 @@
+// services/account_number.go
+package services
+
+import (
+    "errors"
+    "strconv"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/utils"
+)
+
+// ErrInvalidAccountRef is returned for a reference that is neither an account
+// number, a valid IBAN nor a numeric ID.
+var ErrInvalidAccountRef = errors.New("invalid account reference")
+
+// AssignAccountIdentifiers generates a unique account number (and IBAN when
+// enabled) for an account. The caller saves the account.
+func AssignAccountIdentifiers(db *gorm.DB, account *models.Account, cfg config.AccountNumberConfig) error {
+    for attempt := 0; attempt < 10; attempt++ {
+        number, err := utils.GenerateAccountNumber(cfg.Length)
+        if err != nil {
+            return err
+        }
+
+        var count int64
+        if err := db.Unscoped().Model(&models.Account{}).Where("account_number = ?", number).Count(&count).Error; err != nil {
+            return err
+        }
+        if count > 0 {
+            continue
+        }
+
+        account.AccountNumber = &number
+        if cfg.IBANEnabled {
+            iban, err := utils.BuildIBAN(cfg.IBANCountry, cfg.IBANBankCode, number)
+            if err != nil {
+                return err
+            }
+            account.IBAN = &iban
+        }
+        return nil
+    }
+    return errors.New("could not generate a unique account number")
+}
+
+// FindAccountByRef loads an account by account number, IBAN or numeric ID.
+// A string of the configured account number length with a valid check digit
+// is treated as an account number; anything else numeric is an ID.
+func FindAccountByRef(db *gorm.DB, ref string, cfg config.AccountNumberConfig, account *models.Account) error {
+    switch {
+    case utils.LooksLikeIBAN(ref):
+        if !utils.ValidateIBAN(ref) {
+            return ErrInvalidAccountRef
+        }
+        return db.Where("iban = ?", utils.NormalizeIBAN(ref)).First(account).Error
+    case utils.ValidateAccountNumber(ref, cfg.Length):
+        return db.Where("account_number = ?", ref).First(account).Error
+    default:
+        id, err := strconv.ParseUint(ref, 10, 64)
+        if err != nil || id == 0 {
+            return ErrInvalidAccountRef
+        }
+        return db.First(account, id).Error
+    }
+}
+
+// ResolveAccountID turns an account reference into an ID. An explicit ID wins.
+func ResolveAccountID(db *gorm.DB, id uint, ref string, cfg config.AccountNumberConfig) (uint, error) {
+    if id != 0 {
+        return id, nil
+    }
+    if ref == "" {
+        return 0, ErrInvalidAccountRef
+    }
+    var account models.Account
+    if err := FindAccountByRef(db, ref, cfg, &account); err != nil {
+        return 0, err
+    }
+    return account.ID, nil
+}
@@ -0,0 +1,104 @@
+// services/adjustment.go
+package services
+
+import (
+    "encoding/json"
+    "fmt"
+    "math"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// BalanceAdjustment is a manual correction of an account balance. A positive
+// amount credits the account, a negative one debits it.
+type BalanceAdjustment struct {
+    AccountID uint    `json:"account_id"`
+    Amount    float64 `json:"amount"`
+    Reason    string  `json:"reason"`
+}
+
+// ProposeAdjustment puts a manual balance adjustment up for a second staff
+// member to approve. Nothing is booked until then.
+func ProposeAdjustment(db *gorm.DB, cfg config.ApprovalConfig, adj BalanceAdjustment, makerID uint) (*models.PendingAction, error) {
+    if adj.Amount == 0 || math.IsNaN(adj.Amount) || math.IsInf(adj.Amount, 0) {
+        return nil, newError(ErrInvalid, "Amount must be a non-zero number")
+    }
+    if adj.Reason == "" {
+        return nil, newError(ErrInvalid, "A reason is required")
+    }
+    var account models.Account
+    if err := db.First(&account, adj.AccountID).Error; err != nil {
+        return nil, notFound(err, "Account not found")
+    }
+    if account.Status == models.AccountStatusClosed {
+        return nil, newError(ErrForbidden, fmt.Sprintf("Account %d is closed", account.ID))
+    }
+    return ProposeAction(db, cfg, ActionProposal{
+        Kind:       models.ActionAdjustBalance,
+        EntityType: "account",
+        EntityID:   account.ID,
+        Payload:    adj,
+        Reason:     adj.Reason,
+        MakerID:    makerID,
+    })
+}
+
+// AdjustBalance books a manual adjustment as an adjustment-in or
+// adjustment-out transaction. It works on frozen accounts, since corrections
+// are often why an account was frozen, but not on closed ones, and it never
+// takes the balance past the overdraft limit.
+func AdjustBalance(db *gorm.DB, adj BalanceAdjustment) (*MovementResult, error) {
+    var result MovementResult
+    err := db.Transaction(func(tx *gorm.DB) error {
+        account := &result.Account
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, adj.AccountID).Error; err != nil {
+            return notFound(err, "Account not found")
+        }
+        if account.Status == models.AccountStatusClosed {
+            return newError(ErrForbidden, fmt.Sprintf("Account %d is closed", account.ID))
+        }
+        if account.AvailableBalance()+adj.Amount < 0 {
+            return newError(ErrInvalid, "Insufficient balance")
+        }
+
+        result.Before = *account
+        account.Balance += adj.Amount
+        if err := tx.Save(account).Error; err != nil {
+            return err
+        }
+        result.Transaction = models.Transaction{
+            AccountID:       account.ID,
+            TransactionType: models.TransactionAdjustmentIn,
+            Amount:          math.Abs(adj.Amount),
+            Description:     "Manual adjustment: " + adj.Reason,
+            BalanceAfter:    account.Balance,
+        }
+        if adj.Amount < 0 {
+            result.Transaction.TransactionType = models.TransactionAdjustmentOut
+        }
+        return tx.Create(&result.Transaction).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    metrics.MoneyMoved(metrics.TypeAdjustment, result.Account.Currency, math.Abs(adj.Amount))
+    return &result, nil
+}
+
+// executeAdjustment runs an approved account.adjust action.
+func executeAdjustment(tx *gorm.DB, _ ActionEnv, action *models.PendingAction) (interface{}, error) {
+    var adj BalanceAdjustment
+    if err := json.Unmarshal([]byte(action.Payload), &adj); err != nil {
+        return nil, err
+    }
+    result, err := AdjustBalance(tx, adj)
+    if err != nil {
+        return nil, err
+    }
+    return result, nil
+}
@@ -0,0 +1,123 @@
+// services/auth.go
+package services
+
+import (
+    "fmt"
+    "time"
+
+    "golang.org/x/crypto/bcrypt"
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+    "github.com/bhushangupta162/bank_management/utils"
+)
+
+// ErrMFARequired is returned by CheckStepUpMFA when a code is needed but was not sent.
+var ErrMFARequired = newError(ErrUnauthenticated, "MFA code required for this transfer")
+
+// LoginResult holds either an access token, or an MFA challenge token when the
+// user has two-factor authentication enabled.
+type LoginResult struct {
+    Token    string
+    MFAToken string
+}
+
+// Login checks an email and password. Users with two-factor authentication
+// enabled get a short-lived challenge token for LoginMFA instead of an access token.
+func Login(db *gorm.DB, mfaCfg config.MFAConfig, email, password string) (*LoginResult, error) {
+    var user models.User
+    if err := db.Where("email = ?", email).First(&user).Error; err != nil {
+        metrics.FailedLogin(metrics.LoginUnknownUser)
+        return nil, newError(ErrUnauthenticated, "Invalid email or password")
+    }
+    if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+        metrics.FailedLogin(metrics.LoginBadPassword)
+        return nil, newError(ErrUnauthenticated, "Invalid email or password")
+    }
+
+    if user.MFAEnabled {
+        challenge, err := utils.GenerateMFAChallengeToken(user.ID, mfaCfg.ChallengeTTL)
+        if err != nil {
+            return nil, err
+        }
+        return &LoginResult{MFAToken: challenge}, nil
+    }
+    token, err := utils.GenerateToken(user.ID)
+    if err != nil {
+        return nil, err
+    }
+    return &LoginResult{Token: token}, nil
+}
+
+// LoginMFA exchanges an MFA challenge token plus a TOTP or recovery code for an access token.
+func LoginMFA(db *gorm.DB, mfaToken, code, recoveryCode string) (string, error) {
+    userID, err := utils.ParseToken(mfaToken, utils.TokenTypeMFAChallenge)
+    if err != nil {
+        return "", newError(ErrUnauthenticated, "Invalid or expired MFA token")
+    }
+    var user models.User
+    if err := db.First(&user, userID).Error; err != nil || !user.MFAEnabled {
+        return "", newError(ErrUnauthenticated, "Invalid or expired MFA token")
+    }
+    if !VerifyTOTP(db, &user, code) && !ConsumeRecoveryCode(db, user.ID, recoveryCode) {
+        metrics.FailedLogin(metrics.LoginBadMFACode)
+        return "", newError(ErrUnauthenticated, "Invalid code")
+    }
+    return utils.GenerateToken(user.ID)
+}
+
+// CheckStepUpMFA enforces a fresh TOTP code for transfers above the configured threshold.
+func CheckStepUpMFA(db *gorm.DB, cfg config.MFAConfig, userID uint, amount float64, code string) error {
+    if cfg.StepUpThreshold <= 0 || amount <= cfg.StepUpThreshold {
+        return nil
+    }
+
+    var user models.User
+    if err := db.First(&user, userID).Error; err != nil {
+        return newError(ErrForbidden, "Account owner not found")
+    }
+    if !user.MFAEnabled {
+        return newError(ErrForbidden, fmt.Sprintf("Two-factor authentication must be enabled for transfers above %.2f", cfg.StepUpThreshold))
+    }
+    if code == "" {
+        return ErrMFARequired
+    }
+    if !VerifyTOTP(db, &user, code) {
+        return newError(ErrUnauthenticated, "Invalid MFA code")
+    }
+    return nil
+}
+
+// VerifyTOTP checks a TOTP code for the user and records the time step so the
+// same code cannot be replayed.
+func VerifyTOTP(db *gorm.DB, user *models.User, code string) bool {
+    if user.TOTPSecret == "" || code == "" {
+        return false
+    }
+    step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+    if !ok {
+        return false
+    }
+    // Conditional update: only one request can claim a given step.
+    res := db.Model(&models.User{}).
+        Where("id = ? AND totp_last_step < ?", user.ID, step).
+        Update("totp_last_step", step)
+    if res.Error != nil || res.RowsAffected != 1 {
+        return false
+    }
+    user.TOTPLastStep = step
+    return true
+}
+
+// ConsumeRecoveryCode marks a matching unused recovery code as used.
+func ConsumeRecoveryCode(db *gorm.DB, userID uint, code string) bool {
+    if code == "" {
+        return false
+    }
+    res := db.Model(&models.RecoveryCode{}).
+        Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, utils.HashToken(code)).
+        Update("used_at", time.Now())
+    return res.Error == nil && res.RowsAffected == 1
+}
@@ -0,0 +1,28 @@
+// services/errors.go
+package services
+
+import "errors"
+
+// Error kinds. Transports map these to status codes (HTTP in handlers/).
+var (
+    ErrInvalid   = errors.New("invalid request")
+    ErrNotFound  = errors.New("not found")
+    ErrForbidden = errors.New("forbidden")
+    ErrConflict  = errors.New("conflict")
+
+    ErrUnauthenticated = errors.New("unauthenticated") // Bad credentials or a missing MFA code
+)
+
+// Error is a business rule failure with a message that is safe to show to clients.
+type Error struct {
+    Kind    error
+    Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+func (e *Error) Unwrap() error { return e.Kind }
+
+func newError(kind error, message string) *Error {
+    return &Error{Kind: kind, Message: message}
+}
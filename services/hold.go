@@ -0,0 +1,300 @@
+// services/hold.go
+package services
+
+import (
+    "errors"
+    "fmt"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// HoldRequest reserves funds on an account. ExpiresAt defaults to HOLD_TTL
+// from now.
+type HoldRequest struct {
+    AccountID   uint
+    Amount      float64
+    Description string
+    Reference   string
+    ExpiresAt   *time.Time
+}
+
+// HoldResult is the outcome of creating, capturing or releasing a hold: the
+// account before and after, the hold, and for a capture the logged debit.
+type HoldResult struct {
+    Before       models.Account      `json:"-"`
+    Account      models.Account      `json:"account"`
+    Hold         models.Hold         `json:"hold"`
+    Transaction  *models.Transaction `json:"transaction,omitempty"`   // The hold-capture debit
+    OverdraftFee *models.Transaction `json:"overdraft_fee,omitempty"` // Charged when the capture goes below zero
+}
+
+// CreateHold reserves funds on an account, like a card authorization. The
+// balance is untouched, but the available balance drops by the amount until
+// the hold is captured, released or expires. Only the account's owner and
+// staff may place one. Status, available balance and outflow limits are
+// checked as for a withdrawal.
+func CreateHold(db *gorm.DB, emailCfg config.EmailConfig, limits config.LimitsConfig, cfg config.HoldConfig, req HoldRequest, createdBy uint) (*HoldResult, error) {
+    if req.Amount <= 0 {
+        return nil, newError(ErrInvalid, "Amount must be positive")
+    }
+    now := time.Now()
+    expiresAt := now.Add(cfg.DefaultTTL)
+    if req.ExpiresAt != nil {
+        if !req.ExpiresAt.After(now) {
+            return nil, newError(ErrInvalid, "expires_at must be in the future")
+        }
+        if cfg.MaxTTL > 0 && req.ExpiresAt.After(now.Add(cfg.MaxTTL)) {
+            return nil, newError(ErrInvalid, fmt.Sprintf("expires_at must be within %s", cfg.MaxTTL))
+        }
+        expiresAt = *req.ExpiresAt
+    }
+
+    var result HoldResult
+    if err := db.First(&result.Account, req.AccountID).Error; err != nil {
+        return nil, notFound(err, "Account not found")
+    }
+    if err := requireAccountAccess(db, createdBy, &result.Account); err != nil {
+        return nil, err
+    }
+    if err := RequireVerifiedEmail(db, emailCfg, result.Account.UserID); err != nil {
+        return nil, err
+    }
+
+    err := db.Transaction(func(tx *gorm.DB) error {
+        account := &result.Account
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, req.AccountID).Error; err != nil {
+            return notFound(err, "Account not found")
+        }
+        if !account.CanDebit() {
+            return newError(ErrForbidden, fmt.Sprintf("Account %d is %s and cannot be debited", account.ID, account.Status))
+        }
+        if account.AvailableBalance() < req.Amount {
+            return newError(ErrInvalid, "Insufficient balance")
+        }
+        if err := CheckOutflowLimits(tx, account, req.Amount, false, limits); err != nil {
+            return err
+        }
+
+        result.Before = *account
+        account.HeldAmount = roundCents(account.HeldAmount + req.Amount)
+        if err := tx.Save(account).Error; err != nil {
+            return err
+        }
+        result.Hold = models.Hold{
+            AccountID:   account.ID,
+            Amount:      req.Amount,
+            Description: req.Description,
+            Reference:   req.Reference,
+            Status:      models.HoldStatusActive,
+            ExpiresAt:   expiresAt,
+            CreatedBy:   createdBy,
+        }
+        if err := tx.Create(&result.Hold).Error; err != nil {
+            return err
+        }
+        return models.RecordEvent(tx, models.EventHoldCreated, "hold", result.Hold.ID, result.Hold)
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &result, nil
+}
+
+// CaptureHold settles a hold into a hold-capture debit. An amount of 0
+// captures the whole hold; a smaller amount captures that much and releases
+// the rest. Expired holds cannot be captured. actorID must own the account or
+// be staff.
+func CaptureHold(db *gorm.DB, holdID uint, amount float64, actorID uint) (*HoldResult, error) {
+    if amount < 0 {
+        return nil, newError(ErrInvalid, "Amount must not be negative")
+    }
+    var result HoldResult
+    err := db.Transaction(func(tx *gorm.DB) error {
+        hold := &result.Hold
+        if err := lockActiveHold(tx, hold, holdID, actorID); err != nil {
+            return err
+        }
+        if amount == 0 {
+            amount = hold.Amount
+        }
+        if amount > hold.Amount {
+            return newError(ErrInvalid, fmt.Sprintf("Cannot capture more than the %.2f held", hold.Amount))
+        }
+        account := &result.Account
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, hold.AccountID).Error; err != nil {
+            return notFound(err, "Account not found")
+        }
+        if !account.CanDebit() {
+            return newError(ErrForbidden, fmt.Sprintf("Account %d is %s and cannot be debited", account.ID, account.Status))
+        }
+
+        result.Before = *account
+        account.Balance = roundCents(account.Balance - amount)
+        account.HeldAmount = roundCents(account.HeldAmount - hold.Amount)
+        if err := tx.Save(account).Error; err != nil {
+            return err
+        }
+        description := hold.Description
+        if description == "" {
+            description = fmt.Sprintf("Capture of hold %d", hold.ID)
+        }
+        result.Transaction = &models.Transaction{
+            AccountID:       account.ID,
+            TransactionType: models.TransactionHoldCapture,
+            Amount:          amount,
+            Description:     description,
+            BalanceAfter:    account.Balance,
+        }
+        if err := tx.Create(result.Transaction).Error; err != nil {
+            return err
+        }
+
+        now := time.Now()
+        hold.Status = models.HoldStatusCaptured
+        hold.CapturedAmount = amount
+        hold.TransactionID = &result.Transaction.ID
+        hold.ClosedAt = &now
+        if err := tx.Save(hold).Error; err != nil {
+            return err
+        }
+        var err error
+        if result.OverdraftFee, err = chargeOverdraftFee(tx, account, result.Before.Balance); err != nil {
+            return err
+        }
+        return models.RecordEvent(tx, models.EventHoldCaptured, "hold", hold.ID, hold)
+    })
+    if err != nil {
+        return nil, err
+    }
+    metrics.MoneyMoved(metrics.TypeHold, result.Account.Currency, amount)
+    if result.OverdraftFee != nil {
+        metrics.MoneyMoved(metrics.TypeOverdraft, result.Account.Currency, result.OverdraftFee.Amount)
+    }
+    return &result, nil
+}
+
+// ReleaseHold cancels an active hold, giving its amount back to the available
+// balance. actorID must own the account or be staff.
+func ReleaseHold(db *gorm.DB, holdID uint, actorID uint) (*HoldResult, error) {
+    var result HoldResult
+    err := db.Transaction(func(tx *gorm.DB) error {
+        if err := lockActiveHold(tx, &result.Hold, holdID, actorID); err != nil {
+            return err
+        }
+        return releaseHold(tx, &result, models.HoldStatusReleased, time.Now())
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &result, nil
+}
+
+// lockActiveHold loads and locks a hold that can still be captured or
+// released, on behalf of the account's owner or staff.
+func lockActiveHold(tx *gorm.DB, hold *models.Hold, holdID, actorID uint) error {
+    if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(hold, holdID).Error; err != nil {
+        return notFound(err, "Hold not found")
+    }
+    var account models.Account
+    if err := tx.Select("id", "user_id").First(&account, hold.AccountID).Error; err != nil {
+        return notFound(err, "Hold not found")
+    }
+    if err := requireAccountAccess(tx, actorID, &account); err != nil {
+        return err
+    }
+    if hold.Status != models.HoldStatusActive {
+        return newError(ErrConflict, fmt.Sprintf("Hold is %s", hold.Status))
+    }
+    if !time.Now().Before(hold.ExpiresAt) {
+        return newError(ErrConflict, "Hold has expired")
+    }
+    return nil
+}
+
+// releaseHold closes result.Hold with the given status and takes its amount
+// off the account's held amount. It must run inside the caller's DB
+// transaction with the hold locked.
+func releaseHold(tx *gorm.DB, result *HoldResult, status string, now time.Time) error {
+    hold, account := &result.Hold, &result.Account
+    if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, hold.AccountID).Error; err != nil {
+        return notFound(err, "Account not found")
+    }
+    result.Before = *account
+    account.HeldAmount = roundCents(account.HeldAmount - hold.Amount)
+    if account.HeldAmount < 0 {
+        account.HeldAmount = 0
+    }
+    if err := tx.Save(account).Error; err != nil {
+        return err
+    }
+    hold.Status = status
+    hold.ClosedAt = &now
+    if err := tx.Save(hold).Error; err != nil {
+        return err
+    }
+    return models.RecordEvent(tx, models.EventHoldReleased, "hold", hold.ID, hold)
+}
+
+// ExpireHolds is the body of the hold expiry job. Every active hold past its
+// expiry is released with status expired. It returns the number of holds
+// expired.
+func ExpireHolds(db *gorm.DB, now time.Time) (int, error) {
+    var ids []uint
+    if err := db.Model(&models.Hold{}).
+        Where("status = ? AND expires_at <= ?", models.HoldStatusActive, now).
+        Pluck("id", &ids).Error; err != nil {
+        return 0, err
+    }
+
+    expired := 0
+    for _, id := range ids {
+        ok, err := expireHold(db, id, now)
+        if err != nil {
+            return expired, fmt.Errorf("hold %d: %w", id, err)
+        }
+        if ok {
+            expired++
+        }
+    }
+    return expired, nil
+}
+
+// expireHold releases one expired hold in its own DB transaction and reports
+// whether it did.
+func expireHold(db *gorm.DB, id uint, now time.Time) (bool, error) {
+    done := false
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var result HoldResult
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).First(&result.Hold, id).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil // Another worker has it
+        }
+        if err != nil {
+            return err
+        }
+        if result.Hold.Status != models.HoldStatusActive || now.Before(result.Hold.ExpiresAt) {
+            return nil
+        }
+        done = true
+        return releaseHold(tx, &result, models.HoldStatusExpired, now)
+    })
+    return done, err
+}
+
+// ListHolds returns an account's holds, newest first, optionally only those
+// with the given status.
+func ListHolds(db *gorm.DB, accountID uint, status string) ([]models.Hold, error) {
+    holds := []models.Hold{}
+    query := db.Where("account_id = ?", accountID)
+    if status != "" {
+        query = query.Where("status = ?", status)
+    }
+    err := query.Order("id DESC").Find(&holds).Error
+    return holds, err
+}
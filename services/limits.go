@@ -0,0 +1,214 @@
+// services/limits.go
+package services
+
+import (
+    "fmt"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// LimitError is returned when a debit would break a limit.
+type LimitError struct {
+    Limit     string  // "single", "daily", "monthly" or "hourly_transfers"
+    Scope     string  // "product" or "user"
+    Remaining float64 // Amount still available in the window
+}
+
+func (e *LimitError) Error() string {
+    switch e.Limit {
+    case "single":
+        return fmt.Sprintf("Amount exceeds the single transaction limit (%s)", e.Scope)
+    case "hourly_transfers":
+        return fmt.Sprintf("Too many transfers in the last hour (%s limit)", e.Scope)
+    default:
+        return fmt.Sprintf("%s outflow limit exceeded (%s), %.2f remaining", e.Limit, e.Scope, e.Remaining)
+    }
+}
+
+// Unwrap lets callers treat limit failures as ErrForbidden.
+func (e *LimitError) Unwrap() error { return ErrForbidden }
+
+// LimitWindow reports the limit, usage and what is left for one window.
+// Limit 0 means unlimited, in which case Remaining is omitted.
+type LimitWindow struct {
+    Limit     float64  `json:"limit"`
+    Used      float64  `json:"used"`
+    Remaining *float64 `json:"remaining,omitempty"`
+}
+
+func newLimitWindow(limit, used float64) LimitWindow {
+    w := LimitWindow{Limit: limit, Used: used}
+    if limit > 0 {
+        remaining := limit - used
+        if remaining < 0 {
+            remaining = 0
+        }
+        w.Remaining = &remaining
+    }
+    return w
+}
+
+// LimitUsage is the usage of one limit set.
+type LimitUsage struct {
+    Scope           string      `json:"scope"`
+    SingleMax       float64     `json:"single_max"`
+    Daily           LimitWindow `json:"daily"`
+    Monthly         LimitWindow `json:"monthly"`
+    HourlyTransfers LimitWindow `json:"hourly_transfers"`
+}
+
+// productLimits returns the limits for an account product, falling back to the configured defaults.
+func productLimits(tx *gorm.DB, product string, defaults config.LimitsConfig) (models.TransactionLimit, error) {
+    var limit models.TransactionLimit
+    err := tx.Where("scope = ? AND product = ?", models.LimitScopeProduct, product).First(&limit).Error
+    if err == gorm.ErrRecordNotFound {
+        return models.TransactionLimit{
+            Scope:               models.LimitScopeProduct,
+            Product:             product,
+            SingleMax:           defaults.SingleMax,
+            DailyMax:            defaults.DailyMax,
+            MonthlyMax:          defaults.MonthlyMax,
+            HourlyTransferCount: defaults.HourlyTransferCount,
+        }, nil
+    }
+    return limit, err
+}
+
+// userLimits returns the user's own limits, or nil when none are set.
+func userLimits(tx *gorm.DB, userID uint) (*models.TransactionLimit, error) {
+    var limit models.TransactionLimit
+    err := tx.Where("scope = ? AND user_id = ?", models.LimitScopeUser, userID).First(&limit).Error
+    if err == gorm.ErrRecordNotFound {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &limit, nil
+}
+
+// startOfDay and startOfMonth use UTC so limits reset at the same moment for everyone.
+func startOfDay(t time.Time) time.Time {
+    t = t.UTC()
+    return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfMonth(t time.Time) time.Time {
+    t = t.UTC()
+    return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// computeLimitUsage sums the outflows that count against a limit. accounts is a
+// list or subquery of the account IDs in scope (one account, or all of a user's accounts).
+// Active holds count as outflows from when they are created; once captured,
+// their hold-capture transaction counts instead.
+func computeLimitUsage(tx *gorm.DB, limit models.TransactionLimit, accounts interface{}, now time.Time) (LimitUsage, error) {
+    sumSince := func(since time.Time) (float64, error) {
+        var booked, held float64
+        if err := tx.Model(&models.Transaction{}).
+            Where("account_id IN (?) AND transaction_type IN ? AND created_at >= ?", accounts, models.OutflowTransactionTypes, since).
+            Select("COALESCE(SUM(amount), 0)").Scan(&booked).Error; err != nil {
+            return 0, err
+        }
+        err := tx.Model(&models.Hold{}).
+            Where("account_id IN (?) AND status = ? AND created_at >= ?", accounts, models.HoldStatusActive, since).
+            Select("COALESCE(SUM(amount), 0)").Scan(&held).Error
+        return booked + held, err
+    }
+
+    daily, err := sumSince(startOfDay(now))
+    if err != nil {
+        return LimitUsage{}, err
+    }
+    monthly, err := sumSince(startOfMonth(now))
+    if err != nil {
+        return LimitUsage{}, err
+    }
+    var transfers int64
+    if err := tx.Model(&models.Transaction{}).
+        Where("account_id IN (?) AND transaction_type = ? AND created_at >= ?", accounts, models.TransactionTransferOut, now.Add(-time.Hour)).
+        Count(&transfers).Error; err != nil {
+        return LimitUsage{}, err
+    }
+
+    return LimitUsage{
+        Scope:           limit.Scope,
+        SingleMax:       limit.SingleMax,
+        Daily:           newLimitWindow(limit.DailyMax, daily),
+        Monthly:         newLimitWindow(limit.MonthlyMax, monthly),
+        HourlyTransfers: newLimitWindow(float64(limit.HourlyTransferCount), float64(transfers)),
+    }, nil
+}
+
+// check returns a LimitError if a debit of amount would break this limit set.
+func (u LimitUsage) check(amount float64, isTransfer bool) error {
+    if u.SingleMax > 0 && amount > u.SingleMax {
+        return &LimitError{Limit: "single", Scope: u.Scope, Remaining: u.SingleMax}
+    }
+    if u.Daily.Remaining != nil && amount > *u.Daily.Remaining {
+        return &LimitError{Limit: "daily", Scope: u.Scope, Remaining: *u.Daily.Remaining}
+    }
+    if u.Monthly.Remaining != nil && amount > *u.Monthly.Remaining {
+        return &LimitError{Limit: "monthly", Scope: u.Scope, Remaining: *u.Monthly.Remaining}
+    }
+    if isTransfer && u.HourlyTransfers.Remaining != nil && *u.HourlyTransfers.Remaining < 1 {
+        return &LimitError{Limit: "hourly_transfers", Scope: u.Scope}
+    }
+    return nil
+}
+
+// AccountLimitUsage returns the product and (if any) user limit usage for an account.
+func AccountLimitUsage(tx *gorm.DB, account *models.Account, defaults config.LimitsConfig, now time.Time) ([]LimitUsage, error) {
+    product := account.Product
+    if product == "" {
+        product = models.DefaultAccountProduct
+    }
+    pl, err := productLimits(tx, product, defaults)
+    if err != nil {
+        return nil, err
+    }
+    productUsage, err := computeLimitUsage(tx, pl, []uint{account.ID}, now)
+    if err != nil {
+        return nil, err
+    }
+    usages := []LimitUsage{productUsage}
+
+    ul, err := userLimits(tx, account.UserID)
+    if err != nil {
+        return nil, err
+    }
+    if ul != nil {
+        userAccounts := tx.Model(&models.Account{}).Select("id").Where("user_id = ?", account.UserID)
+        userUsage, err := computeLimitUsage(tx, *ul, userAccounts, now)
+        if err != nil {
+            return nil, err
+        }
+        usages = append(usages, userUsage)
+    }
+    return usages, nil
+}
+
+// CheckOutflowLimits must run inside the debit's DB transaction with the
+// account row already locked. It also locks the owner's user row so that
+// concurrent debits from different accounts of the same user are serialized
+// for the user-level totals.
+func CheckOutflowLimits(tx *gorm.DB, account *models.Account, amount float64, isTransfer bool, defaults config.LimitsConfig) error {
+    if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.User{}, account.UserID).Error; err != nil {
+        return err
+    }
+    usages, err := AccountLimitUsage(tx, account, defaults, time.Now())
+    if err != nil {
+        return err
+    }
+    for _, u := range usages {
+        if err := u.check(amount, isTransfer); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -0,0 +1,218 @@
+// services/loan.go
+package services
+
+import (
+    "encoding/json"
+    "fmt"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// LoanApplication is a request for a new loan under a loan product. The
+// interest rate is not part of it: underwriting prices it.
+type LoanApplication struct {
+    UserID     uint
+    ProductID  uint
+    Principal  float64
+    TermMonths int
+}
+
+// LoanUpdate holds a loan before and after a change.
+type LoanUpdate struct {
+    Before models.Loan
+    Loan   models.Loan
+}
+
+// ApplyLoan creates a loan application under a product and runs it through
+// underwriting, which prices the rate within the product's band and stores
+// its decision, score and reason codes on the loan. The product's terms are
+// copied onto the loan. With cfg.AutoDecide an approved application becomes
+// approved (ready for payout) and a declined one rejected straight away;
+// referred ones stay pending for staff.
+func ApplyLoan(db *gorm.DB, cfg config.UnderwritingConfig, app LoanApplication) (*models.Loan, error) {
+    if app.Principal <= 0 || app.TermMonths <= 0 {
+        return nil, newError(ErrInvalid, "principal and term_months must be positive")
+    }
+    var user models.User
+    if err := db.First(&user, app.UserID).Error; err != nil {
+        return nil, notFound(err, "User not found")
+    }
+    product, err := availableLoanProduct(db, app)
+    if err != nil {
+        return nil, err
+    }
+    now := time.Now()
+    u, err := Underwrite(db, cfg, app, product, now)
+    if err != nil {
+        return nil, err
+    }
+    profile, err := json.Marshal(u.Profile)
+    if err != nil {
+        return nil, err
+    }
+
+    loan := models.Loan{
+        UserID:               app.UserID,
+        Principal:            app.Principal,
+        InterestRate:         u.InterestRate,
+        TermMonths:           app.TermMonths,
+        Status:               models.LoanStatusPending,
+        OutstandingBalance:   app.Principal,
+        UnderwritingDecision: u.Decision,
+        CreditScore:          u.Score,
+        ReasonCodes:          strings.Join(u.Reasons, ","),
+        CreditProfile:        string(profile),
+        ProductID:            &product.ID,
+        ProductCode:          product.Code,
+        OriginationFee:       roundCents(app.Principal * product.OriginationFeePercent / 100),
+        LoanFeeTerms:         product.LoanFeeTerms,
+    }
+    err = db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Create(&loan).Error; err != nil {
+            return err
+        }
+        if !cfg.AutoDecide || u.Decision == models.UnderwritingRefer {
+            return nil
+        }
+        loan.DecidedAt = &now
+        if u.Decision == models.UnderwritingDecline {
+            return transitionLoan(tx, &loan, models.LoanStatusRejected)
+        }
+        return transitionLoan(tx, &loan, models.LoanStatusApproved)
+    })
+    if err != nil {
+        return nil, err
+    }
+    metrics.UnderwritingDecision(u.Decision)
+    metrics.LoanStatus(models.LoanStatusPending)
+    if loan.Status != models.LoanStatusPending {
+        metrics.LoanStatus(loan.Status)
+    }
+    return &loan, nil
+}
+
+// DecideLoan moves a loan to one of the statuses staff set by hand (see
+// models.ManualLoanStatuses) and records who decided on pending loans.
+// Writing a loan off waives its unpaid installments.
+func DecideLoan(db *gorm.DB, loanID uint, status string, deciderID uint) (*LoanUpdate, error) {
+    if !isManualLoanStatus(status) {
+        return nil, newError(ErrInvalid, "Invalid status")
+    }
+    var update LoanUpdate
+    err := db.Transaction(func(tx *gorm.DB) error {
+        loan := &update.Loan
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(loan, loanID).Error; err != nil {
+            return notFound(err, "Loan not found")
+        }
+        update.Before = *loan
+        if loan.Status == models.LoanStatusPending {
+            if deciderID > 0 {
+                loan.DecidedByID = &deciderID
+            }
+            now := time.Now()
+            loan.DecidedAt = &now
+        }
+        if status == models.LoanStatusWrittenOff {
+            if err := tx.Model(&models.LoanInstallment{}).
+                Where("loan_id = ? AND status = ?", loan.ID, models.InstallmentDue).
+                Update("status", models.InstallmentWaived).Error; err != nil {
+                return err
+            }
+        }
+        return transitionLoan(tx, loan, status)
+    })
+    if err != nil {
+        return nil, err
+    }
+    metrics.LoanStatus(update.Loan.Status)
+    return &update, nil
+}
+
+func isManualLoanStatus(status string) bool {
+    for _, s := range models.ManualLoanStatuses {
+        if s == status {
+            return true
+        }
+    }
+    return false
+}
+
+// transitionLoan moves a loan to another status if the state machine allows
+// it, saving it with a loan.status_changed event.
+func transitionLoan(tx *gorm.DB, loan *models.Loan, status string) error {
+    if !loan.CanTransitionTo(status) {
+        return newError(ErrConflict, fmt.Sprintf("A %s loan cannot become %s", loan.Status, status))
+    }
+    from := loan.Status
+    loan.Status = status
+    return saveLoanWithEvent(tx, loan, from)
+}
+
+// saveLoanWithEvent saves the loan and, if its status changed, records a
+// loan.status_changed event in the same DB transaction.
+func saveLoanWithEvent(db *gorm.DB, loan *models.Loan, previousStatus string) error {
+    return db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Save(loan).Error; err != nil {
+            return err
+        }
+        if loan.Status == previousStatus {
+            return nil
+        }
+        return models.RecordEvent(tx, models.EventLoanStatusChanged, "loan", loan.ID, map[string]interface{}{
+            "loan":        loan,
+            "from_status": previousStatus,
+            "to_status":   loan.Status,
+        })
+    })
+}
+
+// loanDecision is the payload of a loan.decide action.
+type loanDecision struct {
+    LoanID uint   `json:"loan_id"`
+    Status string `json:"status"` // One of models.ManualLoanStatuses
+}
+
+// ProposeLoanDecision puts a staff member's status change of a loan (approve,
+// reject, cancel, default, write off) up for a second staff member to confirm.
+// The state machine must allow the change now; it is checked again on approval.
+func ProposeLoanDecision(db *gorm.DB, cfg config.ApprovalConfig, loanID uint, decision string, makerID uint) (*models.PendingAction, error) {
+    if !isManualLoanStatus(decision) {
+        return nil, newError(ErrInvalid, "status must be one of "+strings.Join(models.ManualLoanStatuses, ", "))
+    }
+    var loan models.Loan
+    if err := db.First(&loan, loanID).Error; err != nil {
+        return nil, notFound(err, "Loan not found")
+    }
+    if !loan.CanTransitionTo(decision) {
+        return nil, newError(ErrConflict, fmt.Sprintf("A %s loan cannot become %s", loan.Status, decision))
+    }
+    return ProposeAction(db, cfg, ActionProposal{
+        Kind:       models.ActionDecideLoan,
+        EntityType: "loan",
+        EntityID:   loan.ID,
+        Payload:    loanDecision{LoanID: loan.ID, Status: decision},
+        MakerID:    makerID,
+        Exclusive:  true,
+    })
+}
+
+// executeLoanDecision runs an approved loan.decide action. The maker is
+// recorded as the loan's decider; the checker is on the action.
+func executeLoanDecision(tx *gorm.DB, _ ActionEnv, action *models.PendingAction) (interface{}, error) {
+    var d loanDecision
+    if err := json.Unmarshal([]byte(action.Payload), &d); err != nil {
+        return nil, err
+    }
+    update, err := DecideLoan(tx, d.LoanID, d.Status, action.MakerID)
+    if err != nil {
+        return nil, err
+    }
+    return update.Loan, nil
+}
@@ -0,0 +1,206 @@
+// services/loan_collection.go
+package services
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// SetRepaymentAccount sets the account the collection job debits a loan's
+// installments from, or stops direct debit when accountID is nil. The account
+// must belong to the borrower and be able to pay.
+func SetRepaymentAccount(db *gorm.DB, loanID uint, accountID *uint) (*LoanUpdate, error) {
+    var update LoanUpdate
+    err := db.Transaction(func(tx *gorm.DB) error {
+        loan := &update.Loan
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(loan, loanID).Error; err != nil {
+            return notFound(err, "Loan not found")
+        }
+        if loan.Status != models.LoanStatusApproved && !loan.IsRepayable() {
+            return newError(ErrConflict, fmt.Sprintf("Loan is %s", loan.Status))
+        }
+        if accountID != nil {
+            var account models.Account
+            if err := tx.First(&account, *accountID).Error; err != nil {
+                return notFound(err, "Account not found")
+            }
+            if account.UserID != loan.UserID {
+                return newError(ErrInvalid, "The account does not belong to the borrower")
+            }
+            if !account.CanDebit() {
+                return newError(ErrForbidden, fmt.Sprintf("Account %d is %s and cannot be debited", account.ID, account.Status))
+            }
+        }
+        update.Before = *loan
+        loan.RepaymentAccountID = accountID
+        return tx.Save(loan).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &update, nil
+}
+
+// RunLoanCollections is the body of the direct debit job. It collects what is
+// due on every disbursed loan with a repayment account, taking what the
+// account holds when it cannot cover it all. A loan is tried at most once a
+// day, so shortfalls are retried on the following days. It returns the number
+// of loans attempted.
+func RunLoanCollections(db *gorm.DB, cfg config.LoanConfig, now time.Time) (int, error) {
+    var ids []uint
+    if err := db.Model(&models.Loan{}).
+        Where("repayment_account_id IS NOT NULL AND status IN ?", []string{models.LoanStatusActive, models.LoanStatusDelinquent, models.LoanStatusDefaulted}).
+        Where("EXISTS (SELECT 1 FROM loan_installments i WHERE i.loan_id = loans.id AND i.status = ? AND i.due_date <= ?)", models.InstallmentDue, now).
+        Pluck("id", &ids).Error; err != nil {
+        return 0, err
+    }
+
+    attempted := 0
+    for _, id := range ids {
+        ran, err := collectLoan(db, cfg, id, now)
+        if err != nil {
+            return attempted, fmt.Errorf("loan %d: %w", id, err)
+        }
+        if ran {
+            attempted++
+        }
+    }
+    return attempted, nil
+}
+
+// collectLoan makes one collection attempt for a loan in its own DB
+// transaction. Business failures (empty, frozen or missing account) are
+// recorded on the attempt; only database errors are returned.
+func collectLoan(db *gorm.DB, cfg config.LoanConfig, id uint, now time.Time) (bool, error) {
+    ran := false
+    var repayment Repayment
+    var currency string
+    var collected float64
+    var fee *models.Transaction
+    err := db.Transaction(func(tx *gorm.DB) error {
+        loan := &repayment.Loan
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).First(loan, id).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil // Another worker has it
+        }
+        if err != nil {
+            return err
+        }
+        if loan.RepaymentAccountID == nil || !loan.IsRepayable() || sameDay(loan.LastCollectionAt, now) {
+            return nil
+        }
+        var installments []models.LoanInstallment
+        if err := tx.Where("loan_id = ? AND status = ? AND due_date <= ?", loan.ID, models.InstallmentDue, now).
+            Find(&installments).Error; err != nil {
+            return err
+        }
+        due := 0.0
+        for i := range installments {
+            due += installments[i].Remaining()
+        }
+        due = roundCents(due)
+        if due <= 0 {
+            return nil
+        }
+        ran = true
+        repayment.Before = *loan
+        loan.LastCollectionAt = &now
+        attempt := models.LoanCollection{LoanID: loan.ID, AccountID: *loan.RepaymentAccountID, Due: due}
+
+        var account models.Account
+        accountErr := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, *loan.RepaymentAccountID).Error
+        if accountErr != nil && !errors.Is(accountErr, gorm.ErrRecordNotFound) {
+            return accountErr
+        }
+        currency = account.Currency
+        amount := roundCents(math.Min(due, account.AvailableBalance()))
+        switch {
+        case accountErr != nil:
+            attempt.Error = "Repayment account not found"
+        case !account.CanDebit():
+            attempt.Error = fmt.Sprintf("Account %d is %s and cannot be debited", account.ID, account.Status)
+        case amount <= 0:
+            attempt.Error = "Insufficient balance"
+        default:
+            balanceBefore := account.Balance
+            account.Balance -= amount
+            if err := tx.Save(&account).Error; err != nil {
+                return err
+            }
+            debit := models.Transaction{
+                AccountID:       account.ID,
+                TransactionType: models.TransactionLoanRepayment,
+                Amount:          amount,
+                Description:     fmt.Sprintf("Loan #%d installment", loan.ID),
+                BalanceAfter:    account.Balance,
+            }
+            if err := tx.Create(&debit).Error; err != nil {
+                return err
+            }
+            if fee, err = chargeOverdraftFee(tx, &account, balanceBefore); err != nil {
+                return err
+            }
+            attempt.Collected = amount
+            collected = amount
+            attempt.TransactionID = &debit.ID
+            if amount < due {
+                attempt.Error = fmt.Sprintf("Partial collection: %.2f of %.2f", amount, due)
+            }
+            if err := applyRepayment(tx, cfg, &repayment, amount, cfg.Prepayment, now); err != nil {
+                return err
+            }
+        }
+        if err := tx.Create(&attempt).Error; err != nil {
+            return err
+        }
+
+        if attempt.Collected >= due {
+            return tx.Save(loan).Error
+        }
+        // Whatever could not be collected is now overdue
+        if attempt.Collected == 0 {
+            for i := range installments {
+                if days := daysPastDue(&installments[i], now); days > loan.DaysPastDue {
+                    loan.DaysPastDue = days
+                }
+            }
+            loan.DelinquencyBucket = models.DelinquencyBucket(loan.DaysPastDue)
+        }
+        if loan.Status != models.LoanStatusActive {
+            return tx.Save(loan).Error
+        }
+        return transitionLoan(tx, loan, models.LoanStatusDelinquent)
+    })
+    if err != nil {
+        return false, err
+    }
+    if ran && repayment.Loan.Status != repayment.Before.Status {
+        metrics.LoanStatus(repayment.Loan.Status)
+    }
+    if collected > 0 {
+        metrics.MoneyMoved(metrics.TypeLoanRepay, currency, collected)
+    }
+    if fee != nil {
+        metrics.MoneyMoved(metrics.TypeOverdraft, currency, fee.Amount)
+    }
+    return ran, nil
+}
+
+// sameDay reports whether t is set and on the same UTC date as now.
+func sameDay(t *time.Time, now time.Time) bool {
+    if t == nil {
+        return false
+    }
+    y1, m1, d1 := t.UTC().Date()
+    y2, m2, d2 := now.UTC().Date()
+    return y1 == y2 && m1 == m2 && d1 == d2
+}
@@ -0,0 +1,156 @@
+// services/loan_product.go
+package services
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "strconv"
+    "strings"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// ValidateLoanProduct checks a product's ranges and normalizes its term list.
+func ValidateLoanProduct(p *models.LoanProduct) error {
+    p.Code = strings.TrimSpace(p.Code)
+    if p.Code == "" || p.Name == "" {
+        return newError(ErrInvalid, "code and name are required")
+    }
+    if p.MinPrincipal < 0 || p.MaxPrincipal <= 0 || p.MinPrincipal > p.MaxPrincipal {
+        return newError(ErrInvalid, "min_principal must not be negative and not above max_principal")
+    }
+    if p.MinRate < 0 || p.MinRate > p.MaxRate {
+        return newError(ErrInvalid, "min_rate must not be negative and not above max_rate")
+    }
+    terms := p.TermMonths()
+    if len(terms) == 0 || len(terms) != len(strings.Split(p.Terms, ",")) {
+        return newError(ErrInvalid, "terms must be a comma separated list of months, e.g. \"12,24,36\"")
+    }
+    parts := make([]string, len(terms))
+    for i, t := range terms {
+        if t <= 0 {
+            return newError(ErrInvalid, "terms must be positive")
+        }
+        parts[i] = strconv.Itoa(t)
+    }
+    p.Terms = strings.Join(parts, ",")
+
+    f := p.LoanFeeTerms
+    if f.OriginationFeePercent < 0 || f.PrepaymentPenaltyPercent < 0 || f.LateFeeFlat < 0 || f.LateFeePercent < 0 || f.LateFeeGraceDays < 0 {
+        return newError(ErrInvalid, "fees must not be negative")
+    }
+    return nil
+}
+
+// CreateLoanProduct adds a product to the catalog. Codes are unique.
+func CreateLoanProduct(db *gorm.DB, p *models.LoanProduct) error {
+    if err := ValidateLoanProduct(p); err != nil {
+        return err
+    }
+    if err := ensureUniqueProductCode(db, p.Code, 0); err != nil {
+        return err
+    }
+    return db.Create(p).Error
+}
+
+// UpdateLoanProduct replaces a product's terms. Loans already applied for keep
+// the terms they were given. It returns the product before the change.
+func UpdateLoanProduct(db *gorm.DB, id uint, p *models.LoanProduct) (*models.LoanProduct, error) {
+    var before models.LoanProduct
+    if err := db.First(&before, id).Error; err != nil {
+        return nil, notFound(err, "Loan product not found")
+    }
+    if err := ValidateLoanProduct(p); err != nil {
+        return nil, err
+    }
+    if err := ensureUniqueProductCode(db, p.Code, id); err != nil {
+        return nil, err
+    }
+    p.ID = before.ID
+    p.CreatedAt = before.CreatedAt
+    if err := db.Save(p).Error; err != nil {
+        return nil, err
+    }
+    return &before, nil
+}
+
+func ensureUniqueProductCode(db *gorm.DB, code string, exceptID uint) error {
+    var existing models.LoanProduct
+    err := db.Where("code = ? AND id <> ?", code, exceptID).First(&existing).Error
+    if err == nil {
+        return newError(ErrConflict, fmt.Sprintf("A loan product with code %q already exists", code))
+    }
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return nil
+    }
+    return err
+}
+
+// availableLoanProduct loads a product an application can be made under and
+// checks the requested principal and term against it.
+func availableLoanProduct(db *gorm.DB, app LoanApplication) (*models.LoanProduct, error) {
+    if app.ProductID == 0 {
+        return nil, newError(ErrInvalid, "product_id is required")
+    }
+    var product models.LoanProduct
+    if err := db.First(&product, app.ProductID).Error; err != nil {
+        return nil, notFound(err, "Loan product not found")
+    }
+    if !product.Active {
+        return nil, newError(ErrInvalid, "Loan product is not available")
+    }
+    if app.Principal < product.MinPrincipal || app.Principal > product.MaxPrincipal {
+        return nil, newError(ErrInvalid, fmt.Sprintf("principal must be between %.2f and %.2f for %s", product.MinPrincipal, product.MaxPrincipal, product.Name))
+    }
+    if !product.AllowsTerm(app.TermMonths) {
+        return nil, newError(ErrInvalid, fmt.Sprintf("term_months must be one of %s for %s", product.Terms, product.Name))
+    }
+    return &product, nil
+}
+
+// EnsureDefaultLoanProduct seeds a "personal" loan product into an empty
+// catalog, so applications work on a fresh database. Its limits and rate band
+// follow the underwriting settings.
+func EnsureDefaultLoanProduct(db *gorm.DB, cfg config.UnderwritingConfig) error {
+    var count int64
+    if err := db.Model(&models.LoanProduct{}).Unscoped().Count(&count).Error; err != nil {
+        return err
+    }
+    if count > 0 {
+        return nil
+    }
+    maxTerm := cfg.MaxTermMonths
+    if maxTerm <= 0 {
+        maxTerm = 84
+    }
+    var terms []string
+    for t := 12; t <= maxTerm; t += 12 {
+        terms = append(terms, strconv.Itoa(t))
+    }
+    if len(terms) == 0 {
+        terms = []string{strconv.Itoa(maxTerm)}
+    }
+    maxPrincipal := cfg.MaxPrincipal
+    if maxPrincipal <= 0 {
+        maxPrincipal = 50000
+    }
+    return CreateLoanProduct(db, &models.LoanProduct{
+        Code:         "personal",
+        Name:         "Personal loan",
+        MinPrincipal: 500,
+        MaxPrincipal: maxPrincipal,
+        Terms:        strings.Join(terms, ","),
+        MinRate:      cfg.BaseRate,
+        MaxRate:      math.Max(cfg.BaseRate, cfg.MaxRate),
+        Active:       true,
+        LoanFeeTerms: models.LoanFeeTerms{
+            OriginationFeePercent: 1,
+            LateFeeFlat:           15,
+            LateFeeGraceDays:      5,
+        },
+    })
+}
@@ -0,0 +1,373 @@
+// services/loan_repayment.go
+package services
+
+import (
+    "fmt"
+    "math"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// Parts of a loan a repayment pays, as named in LOAN_REPAYMENT_ORDER.
+const (
+    RepayFees      = "fees"
+    RepayInterest  = "interest"
+    RepayPrincipal = "principal"
+)
+
+// What principal paid ahead of schedule does to the remaining installments.
+const (
+    PrepaymentShortenTerm       = "shorten_term"       // Same installment, fewer of them
+    PrepaymentReduceInstallment = "reduce_installment" // Same number of installments, each smaller
+)
+
+// PayoffQuote is what it costs to close a loan on a given date.
+type PayoffQuote struct {
+    LoanID            uint      `json:"loan_id"`
+    AsOf              time.Time `json:"as_of"`
+    Principal         float64   `json:"principal"`          // All principal still owed
+    AccruedInterest   float64   `json:"accrued_interest"`   // Unpaid interest of due installments, plus the current month's so far
+    Fees              float64   `json:"fees"`               // Unpaid late fees
+    PrepaymentPenalty float64   `json:"prepayment_penalty"` // On the principal not yet due
+    Total             float64   `json:"total"`
+}
+
+// RepaymentRequest is a payment towards a loan.
+type RepaymentRequest struct {
+    LoanID     uint
+    Amount     float64
+    Prepayment string // PrepaymentShortenTerm or PrepaymentReduceInstallment; empty for the configured default
+}
+
+// RepaymentAllocation shows where a payment went.
+type RepaymentAllocation struct {
+    Fees              float64 `json:"fees"`
+    Interest          float64 `json:"interest"`
+    Principal         float64 `json:"principal"`          // Principal of installments that were due
+    PrepaidPrincipal  float64 `json:"prepaid_principal"`  // Principal paid ahead of schedule
+    PrepaymentPenalty float64 `json:"prepayment_penalty"` // Charged on PrepaidPrincipal
+}
+
+// Repayment is the outcome of a payment towards a loan.
+type Repayment struct {
+    Before                models.Loan         `json:"-"`
+    Loan                  models.Loan         `json:"loan"`
+    Allocation            RepaymentAllocation `json:"allocation"`
+    Prepayment            string              `json:"prepayment,omitempty"` // Set when principal was paid ahead of schedule and the loan stays open
+    RemainingInstallments int                 `json:"remaining_installments"`
+}
+
+// LoanPayoff quotes what it costs to repay a loan in full on the given date,
+// which must not be in the past.
+func LoanPayoff(db *gorm.DB, loanID uint, asOf time.Time) (*PayoffQuote, error) {
+    if asOf.Before(time.Now().Truncate(24 * time.Hour)) {
+        return nil, newError(ErrInvalid, "date must not be in the past")
+    }
+    var loan models.Loan
+    if err := db.First(&loan, loanID).Error; err != nil {
+        return nil, notFound(err, "Loan not found")
+    }
+    if !loan.IsRepayable() {
+        return nil, newError(ErrInvalid, "Loan is not active for repayment")
+    }
+    var installments []models.LoanInstallment
+    if err := db.Where("loan_id = ? AND status = ?", loan.ID, models.InstallmentDue).
+        Order("number").Find(&installments).Error; err != nil {
+        return nil, err
+    }
+    quote := payoffQuote(&loan, installments, asOf)
+    return &quote, nil
+}
+
+// payoffQuote prices a payoff from a loan's unpaid installments. Interest of
+// the installment that is not due yet accrues by the day since the previous
+// due date; its remainder is not owed on payoff. Loans paid out before
+// repayment schedules existed owe just their outstanding balance.
+func payoffQuote(loan *models.Loan, installments []models.LoanInstallment, asOf time.Time) PayoffQuote {
+    quote := PayoffQuote{LoanID: loan.ID, AsOf: asOf}
+    if len(installments) == 0 {
+        quote.Principal = loan.OutstandingBalance
+        quote.Total = loan.OutstandingBalance
+        return quote
+    }
+    var notDue float64
+    current := true
+    for i := range installments {
+        inst := &installments[i]
+        quote.Fees += inst.LateFee - inst.PaidLateFee
+        quote.Principal += inst.Principal - inst.PaidPrincipal
+        if !inst.DueDate.After(asOf) {
+            quote.AccruedInterest += inst.Interest - inst.PaidInterest
+            continue
+        }
+        notDue += inst.Principal - inst.PaidPrincipal
+        if current {
+            quote.AccruedInterest += math.Max(0, accruedInterest(inst, asOf)-inst.PaidInterest)
+            current = false
+        }
+    }
+    quote.Principal = roundCents(quote.Principal)
+    quote.AccruedInterest = roundCents(quote.AccruedInterest)
+    quote.Fees = roundCents(quote.Fees)
+    quote.PrepaymentPenalty = roundCents(notDue * loan.PrepaymentPenaltyPercent / 100)
+    quote.Total = roundCents(quote.Principal + quote.AccruedInterest + quote.Fees + quote.PrepaymentPenalty)
+    return quote
+}
+
+// accruedInterest is the part of an installment's interest earned by asOf,
+// counting days from a month before its due date.
+func accruedInterest(inst *models.LoanInstallment, asOf time.Time) float64 {
+    start := inst.DueDate.AddDate(0, -1, 0)
+    period := inst.DueDate.Sub(start)
+    elapsed := asOf.Sub(start)
+    if elapsed <= 0 || period <= 0 {
+        return 0
+    }
+    return roundCents(inst.Interest * math.Min(1, float64(elapsed)/float64(period)))
+}
+
+// RepayLoan applies a payment to a disbursed loan. What is due is paid first,
+// in the order of cfg.RepaymentOrder; the rest settles the interest accrued on
+// the current installment and then pays principal ahead of schedule, less the
+// product's prepayment penalty, and either shortens the term or lowers the
+// remaining installments. A payment that covers the payoff quote, or prepays
+// all the remaining principal, closes the loan; paying more than the quote is
+// refused.
+func RepayLoan(db *gorm.DB, cfg config.LoanConfig, req RepaymentRequest) (*Repayment, error) {
+    if req.Amount <= 0 {
+        return nil, newError(ErrInvalid, "Repayment amount must be positive")
+    }
+    if req.Prepayment == "" {
+        req.Prepayment = cfg.Prepayment
+    }
+    if req.Prepayment != PrepaymentShortenTerm && req.Prepayment != PrepaymentReduceInstallment {
+        return nil, newError(ErrInvalid, "prepayment must be shorten_term or reduce_installment")
+    }
+    var repayment Repayment
+    err := db.Transaction(func(tx *gorm.DB) error {
+        loan := &repayment.Loan
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(loan, req.LoanID).Error; err != nil {
+            return notFound(err, "Loan not found")
+        }
+        if !loan.IsRepayable() {
+            return newError(ErrInvalid, "Loan is not active for repayment")
+        }
+        repayment.Before = *loan
+        return applyRepayment(tx, cfg, &repayment, req.Amount, req.Prepayment, time.Now())
+    })
+    if err != nil {
+        return nil, err
+    }
+    if repayment.Loan.Status != repayment.Before.Status {
+        metrics.LoanStatus(repayment.Loan.Status)
+    }
+    return &repayment, nil
+}
+
+// applyRepayment allocates a payment to the locked loan in repayment.Loan and
+// saves the loan, its installments and any status change.
+func applyRepayment(tx *gorm.DB, cfg config.LoanConfig, repayment *Repayment, amount float64, mode string, now time.Time) error {
+    loan := &repayment.Loan
+    var installments []models.LoanInstallment
+    if err := tx.Where("loan_id = ? AND status = ?", loan.ID, models.InstallmentDue).
+        Order("number").Find(&installments).Error; err != nil {
+        return err
+    }
+    quote := payoffQuote(loan, installments, now)
+    if amount > quote.Total+0.005 {
+        return newError(ErrInvalid, fmt.Sprintf("Amount exceeds the payoff amount of %.2f", quote.Total))
+    }
+
+    alloc := &repayment.Allocation
+    if len(installments) == 0 {
+        alloc.Principal = roundCents(amount)
+        loan.OutstandingBalance = roundCents(loan.OutstandingBalance - amount)
+    } else {
+        left := roundCents(amount)
+        pay := func(owed float64, paid *float64, total *float64) {
+            p := math.Min(left, roundCents(owed-*paid))
+            if p > 0 {
+                *paid = roundCents(*paid + p)
+                *total = roundCents(*total + p)
+                left = roundCents(left - p)
+            }
+        }
+        var due, future []int
+        for i := range installments {
+            if installments[i].DueDate.After(now) {
+                future = append(future, i)
+            } else {
+                due = append(due, i)
+            }
+        }
+        for _, part := range repaymentOrder(cfg.RepaymentOrder) {
+            switch part {
+            case RepayFees:
+                for i := range installments {
+                    pay(installments[i].LateFee, &installments[i].PaidLateFee, &alloc.Fees)
+                }
+            case RepayInterest:
+                for _, i := range due {
+                    pay(installments[i].Interest, &installments[i].PaidInterest, &alloc.Interest)
+                }
+            case RepayPrincipal:
+                for _, i := range due {
+                    pay(installments[i].Principal, &installments[i].PaidPrincipal, &alloc.Principal)
+                }
+            }
+        }
+
+        if left > 0 && len(future) > 0 {
+            payoff := left >= payoffQuote(loan, installments, now).Total-0.005
+            // Interest earned so far on the current installment is settled first
+            current := &installments[future[0]]
+            pay(accruedInterest(current, now), &current.PaidInterest, &alloc.Interest)
+            notDue := 0.0
+            for _, i := range future {
+                notDue += installments[i].Principal - installments[i].PaidPrincipal
+            }
+            prepaid := roundCents(left / (1 + loan.PrepaymentPenaltyPercent/100))
+            if payoff || prepaid >= roundCents(notDue)-0.005 {
+                // Payoff: interest is owed only up to today
+                for k, i := range future {
+                    inst := &installments[i]
+                    accrued := 0.0
+                    if k == 0 {
+                        accrued = accruedInterest(inst, now)
+                    }
+                    inst.Interest = math.Max(inst.PaidInterest, accrued)
+                }
+                for _, i := range future {
+                    pay(installments[i].Interest, &installments[i].PaidInterest, &alloc.Interest)
+                    pay(installments[i].Principal, &installments[i].PaidPrincipal, &alloc.PrepaidPrincipal)
+                }
+                alloc.PrepaymentPenalty = left
+            } else if prepaid > 0 {
+                alloc.PrepaidPrincipal = prepaid
+                alloc.PrepaymentPenalty = roundCents(left - prepaid)
+                removed, err := reschedule(loan, installments, future, prepaid, mode)
+                if err != nil {
+                    return err
+                }
+                for _, inst := range removed {
+                    if err := tx.Delete(&inst).Error; err != nil {
+                        return err
+                    }
+                }
+                installments = keepInstallments(installments, removed)
+                repayment.Prepayment = mode
+            }
+        }
+
+        for i := range installments {
+            inst := &installments[i]
+            if inst.Remaining() < 0.005 {
+                inst.Status = models.InstallmentPaid
+                inst.PaidAt = &now
+            }
+            if err := tx.Save(inst).Error; err != nil {
+                return err
+            }
+            if inst.Status == models.InstallmentDue {
+                repayment.RemainingInstallments++
+            }
+        }
+        refreshLoanBalances(loan, installments, now)
+    }
+
+    status := loan.Status
+    if loan.OutstandingBalance <= 0 && loan.FeesOutstanding <= 0 {
+        loan.OutstandingBalance = 0
+        status = models.LoanStatusClosed // fully repaid
+        repayment.Prepayment = ""
+    } else if loan.Status == models.LoanStatusDelinquent && loan.DaysPastDue == 0 {
+        status = models.LoanStatusActive // cured
+    }
+    if status == loan.Status {
+        return tx.Save(loan).Error
+    }
+    return transitionLoan(tx, loan, status)
+}
+
+// repaymentOrder cleans up a configured allocation order: unknown parts are
+// dropped and missing ones are added in the default order.
+func repaymentOrder(configured []string) []string {
+    var order []string
+    seen := map[string]bool{}
+    for _, part := range append(configured, RepayFees, RepayInterest, RepayPrincipal) {
+        part = strings.TrimSpace(part)
+        if (part == RepayFees || part == RepayInterest || part == RepayPrincipal) && !seen[part] {
+            seen[part] = true
+            order = append(order, part)
+        }
+    }
+    return order
+}
+
+// reschedule takes prepaid principal off the installments at the given
+// indexes (those not yet due) and spreads the rest over them again. The
+// current month's interest stays as scheduled; later months are charged on the
+// lower principal. With PrepaymentShortenTerm the installment amount stays and
+// the installments no longer needed are returned for deletion; with
+// PrepaymentReduceInstallment every remaining installment gets smaller (the
+// current one keeps its extra interest on top).
+func reschedule(loan *models.Loan, installments []models.LoanInstallment, future []int, prepaid float64, mode string) ([]models.LoanInstallment, error) {
+    balance := -prepaid
+    for _, i := range future {
+        balance += installments[i].Principal - installments[i].PaidPrincipal
+    }
+    balance = roundCents(balance)
+    if balance <= 0 {
+        return nil, newError(ErrInvalid, fmt.Sprintf("A prepayment of %.2f leaves nothing to reschedule; pay off the loan instead", prepaid))
+    }
+
+    rate := loan.InterestRate / 100 / 12
+    payment := loan.InstallmentAmount
+    if mode == PrepaymentReduceInstallment {
+        payment = roundCents(monthlyInstallment(balance, loan.InterestRate, len(future)))
+        loan.InstallmentAmount = payment
+    }
+    var removed []models.LoanInstallment
+    for k, i := range future {
+        inst := &installments[i]
+        if balance <= 0 {
+            removed = append(removed, *inst)
+            continue
+        }
+        interest := roundCents(balance * rate)
+        if k > 0 {
+            inst.Interest = interest
+        } else if mode == PrepaymentShortenTerm {
+            interest = inst.Interest // The installment keeps its amount
+        }
+        principal := math.Max(0, roundCents(payment-interest))
+        if principal > balance || k == len(future)-1 {
+            principal = balance
+        }
+        inst.Principal = roundCents(inst.PaidPrincipal + principal)
+        balance = roundCents(balance - principal)
+    }
+    return removed, nil
+}
+
+// keepInstallments returns installments without the removed ones.
+func keepInstallments(installments, removed []models.LoanInstallment) []models.LoanInstallment {
+    gone := map[uint]bool{}
+    for _, inst := range removed {
+        gone[inst.ID] = true
+    }
+    kept := installments[:0]
+    for _, inst := range installments {
+        if !gone[inst.ID] {
+            kept = append(kept, inst)
+        }
+    }
+    return kept
+}
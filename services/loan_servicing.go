@@ -0,0 +1,272 @@
+// services/loan_servicing.go
+package services
+
+import (
+    "errors"
+    "fmt"
+    "math"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// Disbursement is the outcome of paying out a loan.
+type Disbursement struct {
+    Before       models.Loan              `json:"-"`
+    Loan         models.Loan              `json:"loan"`
+    Transaction  models.Transaction       `json:"transaction"` // The loan-payout credit
+    Installments []models.LoanInstallment `json:"installments"`
+}
+
+// DisburseLoan pays an approved loan out into one of the borrower's accounts
+// and activates it. The account is credited with the principal less the
+// origination fee, and the repayment schedule starts a month later.
+func DisburseLoan(db *gorm.DB, loanID, accountID uint) (*Disbursement, error) {
+    var d Disbursement
+    var currency string
+    err := db.Transaction(func(tx *gorm.DB) error {
+        loan := &d.Loan
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(loan, loanID).Error; err != nil {
+            return notFound(err, "Loan not found")
+        }
+        if loan.Status != models.LoanStatusApproved {
+            return newError(ErrConflict, fmt.Sprintf("Loan is %s; only approved loans can be paid out", loan.Status))
+        }
+        var account models.Account
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, accountID).Error; err != nil {
+            return notFound(err, "Account not found")
+        }
+        if account.UserID != loan.UserID {
+            return newError(ErrInvalid, "The account does not belong to the borrower")
+        }
+        if !account.CanCredit() {
+            return newError(ErrForbidden, fmt.Sprintf("Account %d is %s and cannot receive funds", account.ID, account.Status))
+        }
+        d.Before = *loan
+        currency = account.Currency
+
+        amount := roundCents(loan.Principal - loan.OriginationFee)
+        account.Balance += amount
+        if err := tx.Save(&account).Error; err != nil {
+            return err
+        }
+        description := fmt.Sprintf("Loan #%d payout", loan.ID)
+        if loan.OriginationFee > 0 {
+            description += fmt.Sprintf(" (origination fee %.2f withheld)", loan.OriginationFee)
+        }
+        d.Transaction = models.Transaction{
+            AccountID:       account.ID,
+            TransactionType: models.TransactionLoanPayout,
+            Amount:          amount,
+            Description:     description,
+            BalanceAfter:    account.Balance,
+        }
+        if err := tx.Create(&d.Transaction).Error; err != nil {
+            return err
+        }
+
+        now := time.Now()
+        d.Installments = buildSchedule(loan, now)
+        if err := tx.Create(&d.Installments).Error; err != nil {
+            return err
+        }
+        loan.DisbursedAt = &now
+        loan.DisbursementAccountID = &account.ID
+        loan.InstallmentAmount = d.Installments[0].Amount()
+        loan.OutstandingBalance = loan.Principal
+        loan.DelinquencyBucket = models.DelinquencyCurrent
+        return transitionLoan(tx, loan, models.LoanStatusActive)
+    })
+    if err != nil {
+        return nil, err
+    }
+    metrics.MoneyMoved(metrics.TypeLoanPayout, currency, d.Transaction.Amount)
+    metrics.LoanStatus(d.Loan.Status)
+    return &d, nil
+}
+
+// buildSchedule splits a loan into equal monthly installments (annuity). Each
+// installment's interest is on the principal still owed; the last one takes
+// whatever principal rounding left over.
+func buildSchedule(loan *models.Loan, start time.Time) []models.LoanInstallment {
+    payment := roundCents(monthlyInstallment(loan.Principal, loan.InterestRate, loan.TermMonths))
+    rate := loan.InterestRate / 100 / 12
+    balance := loan.Principal
+    installments := make([]models.LoanInstallment, loan.TermMonths)
+    for k := range installments {
+        interest := roundCents(balance * rate)
+        principal := roundCents(payment - interest)
+        if k == len(installments)-1 || principal > balance {
+            principal = roundCents(balance)
+        }
+        balance = roundCents(balance - principal)
+        installments[k] = models.LoanInstallment{
+            LoanID:    loan.ID,
+            Number:    k + 1,
+            DueDate:   monthlyDate(start.Year(), start.Month()+time.Month(k+1), start.Day(), start),
+            Principal: principal,
+            Interest:  interest,
+            Status:    models.InstallmentDue,
+        }
+    }
+    return installments
+}
+
+// refreshLoanBalances sets a loan's outstanding principal, unpaid fees, days
+// past due and bucket from its installments.
+func refreshLoanBalances(loan *models.Loan, installments []models.LoanInstallment, now time.Time) {
+    var principal, fees float64
+    dpd := 0
+    for i := range installments {
+        inst := &installments[i]
+        if inst.Status != models.InstallmentDue {
+            continue
+        }
+        principal += inst.Principal - inst.PaidPrincipal
+        fees += inst.LateFee - inst.PaidLateFee
+        if days := daysPastDue(inst, now); days > dpd {
+            dpd = days
+        }
+    }
+    loan.OutstandingBalance = roundCents(principal)
+    loan.FeesOutstanding = roundCents(fees)
+    loan.DaysPastDue = dpd
+    loan.DelinquencyBucket = models.DelinquencyBucket(dpd)
+}
+
+// daysPastDue counts the days, started ones included, since an unpaid
+// installment fell due: it is 1 as soon as the due date has passed.
+func daysPastDue(inst *models.LoanInstallment, now time.Time) int {
+    if !inst.IsOverdue(now) {
+        return 0
+    }
+    return int(math.Ceil(now.Sub(inst.DueDate).Hours() / 24))
+}
+
+// LoanSchedule returns a loan's installments in order.
+func LoanSchedule(db *gorm.DB, loanID uint) ([]models.LoanInstallment, error) {
+    installments := []models.LoanInstallment{}
+    err := db.Where("loan_id = ?", loanID).Order("number").Find(&installments).Error
+    return installments, err
+}
+
+// RunLoanDelinquency is the body of the daily delinquency job. For every
+// disbursed, unpaid loan it charges late fees on installments past their
+// grace period, recomputes days past due and moves the loan between active,
+// delinquent and defaulted. It returns the number of loans whose status
+// changed.
+func RunLoanDelinquency(db *gorm.DB, cfg config.LoanConfig, now time.Time) (int, error) {
+    var ids []uint
+    if err := db.Model(&models.Loan{}).
+        Where("status IN ?", []string{models.LoanStatusActive, models.LoanStatusDelinquent}).
+        Pluck("id", &ids).Error; err != nil {
+        return 0, err
+    }
+
+    changed := 0
+    for _, id := range ids {
+        moved, err := updateDelinquency(db, cfg, id, now)
+        if err != nil {
+            return changed, fmt.Errorf("loan %d: %w", id, err)
+        }
+        if moved {
+            changed++
+        }
+    }
+    return changed, nil
+}
+
+// updateDelinquency runs the delinquency rules for one loan in its own DB
+// transaction and reports whether its status changed.
+func updateDelinquency(db *gorm.DB, cfg config.LoanConfig, id uint, now time.Time) (bool, error) {
+    var before, after string
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var loan models.Loan
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).First(&loan, id).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil // Another worker has it
+        }
+        if err != nil {
+            return err
+        }
+        before, after = loan.Status, loan.Status
+        if loan.Status != models.LoanStatusActive && loan.Status != models.LoanStatusDelinquent {
+            return nil
+        }
+
+        var installments []models.LoanInstallment
+        if err := tx.Where("loan_id = ? AND status = ?", loan.ID, models.InstallmentDue).
+            Order("number").Find(&installments).Error; err != nil {
+            return err
+        }
+        if len(installments) == 0 {
+            return nil // Paid out before repayment schedules existed
+        }
+        for i := range installments {
+            if err := chargeLateFee(tx, &loan, &installments[i], now); err != nil {
+                return err
+            }
+        }
+        refreshLoanBalances(&loan, installments, now)
+
+        status := loan.Status
+        if loan.DaysPastDue > 0 {
+            status = models.LoanStatusDelinquent
+            if cfg.DefaultAfterDays > 0 && loan.DaysPastDue >= cfg.DefaultAfterDays {
+                status = models.LoanStatusDefaulted
+            }
+        } else if loan.Status == models.LoanStatusDelinquent {
+            status = models.LoanStatusActive
+        }
+        if status == loan.Status {
+            return tx.Save(&loan).Error
+        }
+        if loan.Status == models.LoanStatusActive && status == models.LoanStatusDefaulted {
+            // Nobody ran the job while it was delinquent; record that step too
+            if err := transitionLoan(tx, &loan, models.LoanStatusDelinquent); err != nil {
+                return err
+            }
+        }
+        if err := transitionLoan(tx, &loan, status); err != nil {
+            return err
+        }
+        after = loan.Status
+        return nil
+    })
+    if err != nil {
+        return false, err
+    }
+    if after != before {
+        metrics.LoanStatus(after)
+        return true, nil
+    }
+    return false, nil
+}
+
+// chargeLateFee charges an installment's late fee once it is unpaid past the
+// loan's grace period. The fee is the loan's flat late fee plus its percentage
+// of the scheduled payment, charged at most once per installment.
+func chargeLateFee(tx *gorm.DB, loan *models.Loan, inst *models.LoanInstallment, now time.Time) error {
+    if inst.LateFee > 0 || daysPastDue(inst, now) <= loan.LateFeeGraceDays {
+        return nil
+    }
+    fee := roundCents(loan.LateFeeFlat + inst.Amount()*loan.LateFeePercent/100)
+    if fee <= 0 {
+        return nil
+    }
+    inst.LateFee = fee
+    if err := tx.Save(inst).Error; err != nil {
+        return err
+    }
+    return models.RecordEvent(tx, models.EventLoanLateFeeCharged, "loan", loan.ID, map[string]interface{}{
+        "loan_id":     loan.ID,
+        "installment": inst.Number,
+        "due_date":    inst.DueDate,
+        "fee":         fee,
+    })
+}
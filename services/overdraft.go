@@ -0,0 +1,178 @@
+// services/overdraft.go
+package services
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// OverdraftTerms are the terms of an account's overdraft facility. A nil rate
+// or fee takes the OVERDRAFT_INTEREST_RATE or OVERDRAFT_FEE default; a zero
+// limit removes the facility.
+type OverdraftTerms struct {
+    Limit        float64  `json:"limit"`
+    InterestRate *float64 `json:"interest_rate"` // Annual, in percent
+    Fee          *float64 `json:"fee"`           // Charged each time a debit takes the balance below zero
+}
+
+// AccountUpdate is an account before and after a change of its terms.
+type AccountUpdate struct {
+    Before  models.Account
+    Account models.Account
+}
+
+// SetOverdraft grants, changes or removes an account's overdraft. Lowering the
+// limit below what is already used is allowed: the account then simply cannot
+// be debited until it is back within the limit.
+func SetOverdraft(db *gorm.DB, cfg config.OverdraftConfig, accountID uint, terms OverdraftTerms) (*AccountUpdate, error) {
+    rate, fee := cfg.InterestRate, cfg.Fee
+    if terms.InterestRate != nil {
+        rate = *terms.InterestRate
+    }
+    if terms.Fee != nil {
+        fee = *terms.Fee
+    }
+    if terms.Limit < 0 || rate < 0 || fee < 0 {
+        return nil, newError(ErrInvalid, "limit, interest_rate and fee must not be negative")
+    }
+    if cfg.MaxLimit > 0 && terms.Limit > cfg.MaxLimit {
+        return nil, newError(ErrInvalid, fmt.Sprintf("limit must not be above %.2f", cfg.MaxLimit))
+    }
+
+    var update AccountUpdate
+    err := db.Transaction(func(tx *gorm.DB) error {
+        account := &update.Account
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, accountID).Error; err != nil {
+            return notFound(err, "Account not found")
+        }
+        if terms.Limit > 0 {
+            if account.Status == models.AccountStatusClosed {
+                return newError(ErrForbidden, fmt.Sprintf("Account %d is closed", account.ID))
+            }
+            if !overdraftProduct(cfg, account.Product) {
+                return newError(ErrInvalid, fmt.Sprintf("Overdrafts are not offered on %s accounts", account.Product))
+            }
+        }
+        update.Before = *account
+        account.OverdraftLimit = terms.Limit
+        account.OverdraftRate = rate
+        account.OverdraftFee = fee
+        return tx.Save(account).Error
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &update, nil
+}
+
+// overdraftProduct reports whether OVERDRAFT_PRODUCTS lists the product.
+func overdraftProduct(cfg config.OverdraftConfig, product string) bool {
+    for _, p := range cfg.Products {
+        if strings.TrimSpace(p) == product {
+            return true
+        }
+    }
+    return false
+}
+
+// chargeOverdraftFee charges the account's overdraft fee when the debit that
+// was just booked took the balance from zero or above to below zero, and
+// returns the fee transaction, or nil when nothing was charged. It must run
+// inside the caller's DB transaction, after the debit is logged.
+func chargeOverdraftFee(tx *gorm.DB, account *models.Account, balanceBefore float64) (*models.Transaction, error) {
+    if balanceBefore < 0 || account.Balance >= 0 || account.OverdraftFee <= 0 {
+        return nil, nil
+    }
+    account.Balance = roundCents(account.Balance - account.OverdraftFee)
+    if err := tx.Save(account).Error; err != nil {
+        return nil, err
+    }
+    fee := &models.Transaction{
+        AccountID:       account.ID,
+        TransactionType: models.TransactionOverdraftFee,
+        Amount:          account.OverdraftFee,
+        Description:     "Overdraft fee",
+        BalanceAfter:    account.Balance,
+    }
+    if err := tx.Create(fee).Error; err != nil {
+        return nil, err
+    }
+    return fee, nil
+}
+
+// RunOverdraftInterest is the body of the overdraft interest job. Every
+// overdrawn account with an interest rate is charged a day's interest on its
+// negative balance, at most once per UTC day. It returns the number of
+// accounts charged.
+func RunOverdraftInterest(db *gorm.DB, now time.Time) (int, error) {
+    var ids []uint
+    if err := db.Model(&models.Account{}).
+        Where("balance < 0 AND overdraft_rate > 0 AND status <> ?", models.AccountStatusClosed).
+        Pluck("id", &ids).Error; err != nil {
+        return 0, err
+    }
+
+    charged := 0
+    for _, id := range ids {
+        ok, err := chargeOverdraftInterest(db, id, now)
+        if err != nil {
+            return charged, fmt.Errorf("account %d: %w", id, err)
+        }
+        if ok {
+            charged++
+        }
+    }
+    return charged, nil
+}
+
+// chargeOverdraftInterest charges one account's daily interest in its own DB
+// transaction and reports whether it did.
+func chargeOverdraftInterest(db *gorm.DB, id uint, now time.Time) (bool, error) {
+    var interest models.Transaction
+    var currency string
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var account models.Account
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).First(&account, id).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil // Another worker has it
+        }
+        if err != nil {
+            return err
+        }
+        if account.Balance >= 0 || account.OverdraftRate <= 0 || sameDay(account.OverdraftChargedAt, now) {
+            return nil
+        }
+        amount := roundCents(-account.Balance * account.OverdraftRate / 100 / 365)
+        account.OverdraftChargedAt = &now
+        if amount <= 0 {
+            return tx.Save(&account).Error
+        }
+        account.Balance = roundCents(account.Balance - amount)
+        if err := tx.Save(&account).Error; err != nil {
+            return err
+        }
+        interest = models.Transaction{
+            AccountID:       account.ID,
+            TransactionType: models.TransactionOverdraftInterest,
+            Amount:          amount,
+            Description:     fmt.Sprintf("Overdraft interest at %.2f%%", account.OverdraftRate),
+            BalanceAfter:    account.Balance,
+        }
+        currency = account.Currency
+        return tx.Create(&interest).Error
+    })
+    if err != nil || interest.ID == 0 {
+        return false, err
+    }
+    metrics.MoneyMoved(metrics.TypeOverdraft, currency, interest.Amount)
+    return true, nil
+}
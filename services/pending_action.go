@@ -0,0 +1,262 @@
+// services/pending_action.go
+package services
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/audit"
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// ActionEnv carries what executors need besides the DB transaction.
+type ActionEnv struct {
+    Limits config.LimitsConfig
+}
+
+// actionExecutor carries out an approved action inside the approval's DB
+// transaction and returns what the response and the action's Result show.
+type actionExecutor func(tx *gorm.DB, env ActionEnv, action *models.PendingAction) (interface{}, error)
+
+// actionExecutors maps each pending action kind to the operation it runs.
+var actionExecutors = map[string]actionExecutor{
+    models.ActionReverseTransaction: executeReversal,
+    models.ActionLargeTransfer:      executeTransfer,
+    models.ActionDecideLoan:         executeLoanDecision,
+    models.ActionAdjustBalance:      executeAdjustment,
+}
+
+// ActionProposal describes an operation to put up for approval.
+type ActionProposal struct {
+    Kind       string
+    EntityType string
+    EntityID   uint
+    Payload    interface{} // Stored as JSON and handed to the executor
+    Reason     string
+    MakerID    uint
+    Exclusive  bool // At most one pending action of this kind per entity
+}
+
+// ActionDecision holds a pending action before and after a checker's decision,
+// and the outcome of the operation when it ran.
+type ActionDecision struct {
+    Before models.PendingAction
+    Action models.PendingAction
+    Result interface{}
+}
+
+// ProposeAction records an operation that runs only once a different user
+// approves it. It expires after cfg.TTL.
+func ProposeAction(db *gorm.DB, cfg config.ApprovalConfig, p ActionProposal) (*models.PendingAction, error) {
+    if _, ok := actionExecutors[p.Kind]; !ok {
+        return nil, fmt.Errorf("no executor for pending action kind %q", p.Kind)
+    }
+    if p.Exclusive {
+        var waiting int64
+        if err := db.Model(&models.PendingAction{}).
+            Where("kind = ? AND entity_type = ? AND entity_id = ? AND status = ? AND expires_at > ?",
+                p.Kind, p.EntityType, p.EntityID, models.ActionStatusPending, time.Now()).
+            Count(&waiting).Error; err != nil {
+            return nil, err
+        }
+        if waiting > 0 {
+            return nil, newError(ErrConflict, "The same request is already awaiting approval")
+        }
+    }
+
+    payload, err := json.Marshal(p.Payload)
+    if err != nil {
+        return nil, err
+    }
+    action := models.PendingAction{
+        Kind:       p.Kind,
+        EntityType: p.EntityType,
+        EntityID:   p.EntityID,
+        Payload:    string(payload),
+        Reason:     p.Reason,
+        Status:     models.ActionStatusPending,
+        MakerID:    p.MakerID,
+        ExpiresAt:  time.Now().Add(cfg.TTL),
+    }
+    if err := db.Create(&action).Error; err != nil {
+        return nil, err
+    }
+    return &action, nil
+}
+
+// ApproveAction runs a pending action on behalf of a checker, who must not be
+// its maker. If the operation is refused (a services.Error) or the action has
+// expired, the new status is saved and the error is returned with the decision.
+func ApproveAction(db *gorm.DB, env ActionEnv, actionID, checkerID uint, note string) (*ActionDecision, error) {
+    return decideAction(db, actionID, checkerID, note, func(tx *gorm.DB, action *models.PendingAction, decision *ActionDecision) error {
+        execute, ok := actionExecutors[action.Kind]
+        if !ok {
+            return fmt.Errorf("no executor for pending action kind %q", action.Kind)
+        }
+        // A savepoint, so a refused operation leaves nothing behind but the failed status
+        execErr := tx.Transaction(func(inner *gorm.DB) error {
+            var err error
+            decision.Result, err = execute(inner, env, action)
+            return err
+        })
+        var svcErr *Error
+        var limitErr *LimitError
+        switch {
+        case execErr == nil:
+            result, err := json.Marshal(decision.Result)
+            if err != nil {
+                return err
+            }
+            action.Status = models.ActionStatusExecuted
+            action.Result = string(result)
+            return nil
+        case errors.As(execErr, &svcErr), errors.As(execErr, &limitErr):
+            action.Status = models.ActionStatusFailed
+            action.Result = execErr.Error()
+            return &refusedError{execErr}
+        default:
+            return execErr
+        }
+    })
+}
+
+// RejectAction closes a pending action without running it.
+func RejectAction(db *gorm.DB, actionID, checkerID uint, note string) (*ActionDecision, error) {
+    return decideAction(db, actionID, checkerID, note, func(_ *gorm.DB, action *models.PendingAction, _ *ActionDecision) error {
+        action.Status = models.ActionStatusRejected
+        return nil
+    })
+}
+
+// refusedError marks an outcome that is saved on the action but still
+// reported to the checker as an error.
+type refusedError struct{ err error }
+
+func (e *refusedError) Error() string { return e.err.Error() }
+
+func (e *refusedError) Unwrap() error { return e.err }
+
+// decideAction locks a pending action, lets apply set the new status and
+// saves it with the checker and time. An action past its expiry is marked
+// expired instead and reported as a conflict.
+func decideAction(db *gorm.DB, actionID, checkerID uint, note string, apply func(tx *gorm.DB, action *models.PendingAction, decision *ActionDecision) error) (*ActionDecision, error) {
+    var decision ActionDecision
+    var outcome error
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var action models.PendingAction
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&action, actionID).Error; err != nil {
+            return notFound(err, "Pending action not found")
+        }
+        now := time.Now()
+        decision.Before = action
+        if action.IsExpired(now) {
+            action.Status = models.ActionStatusExpired
+            outcome = newError(ErrConflict, "Action expired at "+action.ExpiresAt.UTC().Format(time.RFC3339))
+            if err := tx.Save(&action).Error; err != nil {
+                return err
+            }
+            decision.Action = action
+            return nil
+        }
+        if action.Status != models.ActionStatusPending {
+            return newError(ErrConflict, "Action is already "+action.Status)
+        }
+        if action.MakerID == checkerID {
+            return newError(ErrForbidden, "An action must be decided by someone other than its maker")
+        }
+
+        if err := apply(tx, &action, &decision); err != nil {
+            var refused *refusedError
+            if !errors.As(err, &refused) {
+                return err
+            }
+            outcome = refused.err
+        }
+        action.CheckerID = &checkerID
+        action.DecidedAt = &now
+        action.Note = note
+        if err := tx.Save(&action).Error; err != nil {
+            return err
+        }
+        decision.Action = action
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &decision, outcome
+}
+
+// ExpirePendingActions marks every pending action past its expiry as expired
+// and records each one in the audit log. It is the body of the expiry job.
+func ExpirePendingActions(db *gorm.DB, now time.Time) (int, error) {
+    var expired []models.PendingAction
+    err := db.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+            Where("status = ? AND expires_at <= ?", models.ActionStatusPending, now).
+            Find(&expired).Error; err != nil {
+            return err
+        }
+        for i := range expired {
+            before := expired[i]
+            expired[i].Status = models.ActionStatusExpired
+            if err := tx.Save(&expired[i]).Error; err != nil {
+                return err
+            }
+            entry := models.AuditEntry{
+                Action:     "SYSTEM pending_action.expired",
+                EntityType: "pending_action",
+                EntityID:   fmt.Sprint(expired[i].ID),
+                Before:     audit.Snapshot(before),
+                After:      audit.Snapshot(expired[i]),
+            }
+            entry.Diff = audit.Diff(entry.Before, entry.After)
+            if err := audit.Append(tx, &entry); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        return 0, err
+    }
+    return len(expired), nil
+}
+
+// RequestReversal proposes a reversal for a checker to approve. The
+// transaction must be reversible now and have no other reversal waiting. The
+// action's entity is the first (debit) leg, whichever leg of a transfer was named.
+func RequestReversal(db *gorm.DB, cfg config.ApprovalConfig, req ReversalRequest, makerID uint) (*models.PendingAction, error) {
+    legs, err := reversibleLegs(db, req.TransactionID)
+    if err != nil {
+        return nil, err
+    }
+    return ProposeAction(db, cfg, ActionProposal{
+        Kind:       models.ActionReverseTransaction,
+        EntityType: "transaction",
+        EntityID:   legs[0].ID,
+        Payload:    req,
+        Reason:     req.Reason,
+        MakerID:    makerID,
+        Exclusive:  true,
+    })
+}
+
+// executeReversal runs an approved transaction.reverse action.
+func executeReversal(tx *gorm.DB, _ ActionEnv, action *models.PendingAction) (interface{}, error) {
+    var req ReversalRequest
+    if err := json.Unmarshal([]byte(action.Payload), &req); err != nil {
+        return nil, err
+    }
+    result, err := ReverseTransaction(tx, req)
+    if err != nil {
+        return nil, err
+    }
+    return result, nil
+}
@@ -0,0 +1,164 @@
+// services/reversal.go
+package services
+
+import (
+    "fmt"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// ReversalRequest asks for a transaction to be undone with compensating entries.
+type ReversalRequest struct {
+    TransactionID uint   `json:"transaction_id"`
+    Reason        string `json:"reason"`
+    AllowNegative bool   `json:"allow_negative"` // Staff only: let the debited account go past its overdraft limit
+}
+
+// ReversalResult holds the original entries, their compensating entries (in
+// the same order) and the accounts after the reversal.
+type ReversalResult struct {
+    Original  []models.Transaction `json:"original"`
+    Reversals []models.Transaction `json:"reversals"`
+    Accounts  []models.Account     `json:"accounts"`
+}
+
+// reversibleLegs loads a transaction and every leg that must be reversed with
+// it (both legs of a transfer, debit first). It rejects reversal entries,
+// unlinked transfer legs and transactions that were already reversed.
+func reversibleLegs(db *gorm.DB, transactionID uint) ([]models.Transaction, error) {
+    var original models.Transaction
+    if err := db.First(&original, transactionID).Error; err != nil {
+        return nil, notFound(err, "Transaction not found")
+    }
+    if original.IsReversal() {
+        return nil, newError(ErrInvalid, "A reversal cannot be reversed")
+    }
+
+    legs := []models.Transaction{original}
+    switch original.TransactionType {
+    case models.TransactionDeposit, models.TransactionWithdrawal:
+    case models.TransactionTransferOut, models.TransactionTransferIn:
+        if original.CounterpartID == nil {
+            return nil, newError(ErrConflict, "This transfer was made before transfers were linked and must be reversed manually")
+        }
+        var counterpart models.Transaction
+        if err := db.First(&counterpart, *original.CounterpartID).Error; err != nil {
+            return nil, notFound(err, "Other leg of the transfer not found")
+        }
+        // Debit leg first, like the transfer itself
+        if original.TransactionType == models.TransactionTransferIn {
+            legs = []models.Transaction{counterpart, original}
+        } else {
+            legs = append(legs, counterpart)
+        }
+    default:
+        return nil, newError(ErrInvalid, fmt.Sprintf("%s transactions cannot be reversed", original.TransactionType))
+    }
+
+    ids := make([]uint, len(legs))
+    for i, leg := range legs {
+        ids[i] = leg.ID
+    }
+    var reversed int64
+    if err := db.Model(&models.Transaction{}).Where("reversal_of_id IN ?", ids).Count(&reversed).Error; err != nil {
+        return nil, err
+    }
+    if reversed > 0 {
+        return nil, newError(ErrConflict, "Transaction was already reversed")
+    }
+    return legs, nil
+}
+
+// ReverseTransaction undoes a deposit, withdrawal or transfer by booking an
+// opposite entry for each leg, linked to the original through ReversalOfID.
+// A credit that is taken back must be covered by the account's available balance unless
+// AllowNegative is set. Everything happens in one DB transaction, with the
+// accounts locked so two reversals of the same transaction cannot both pass.
+func ReverseTransaction(db *gorm.DB, req ReversalRequest) (*ReversalResult, error) {
+    var result ReversalResult
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var accountIDs []uint
+        if err := tx.Model(&models.Transaction{}).
+            Where("id = ? OR counterpart_id = ?", req.TransactionID, req.TransactionID).
+            Distinct().Pluck("account_id", &accountIDs).Error; err != nil {
+            return err
+        }
+        // Lock in ID order, like transfers, so reversals cannot deadlock with them
+        var accounts []models.Account
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+            Where("id IN ?", accountIDs).Order("id").Find(&accounts).Error; err != nil {
+            return err
+        }
+
+        legs, err := reversibleLegs(tx, req.TransactionID)
+        if err != nil {
+            return err
+        }
+        byID := map[uint]*models.Account{}
+        for i := range accounts {
+            byID[accounts[i].ID] = &accounts[i]
+        }
+
+        for i := range legs {
+            leg := &legs[i]
+            account, ok := byID[leg.AccountID]
+            if !ok {
+                return newError(ErrNotFound, fmt.Sprintf("Account %d not found", leg.AccountID))
+            }
+            if account.Status == models.AccountStatusClosed {
+                return newError(ErrForbidden, fmt.Sprintf("Account %d is closed", account.ID))
+            }
+
+            reversal := models.Transaction{
+                AccountID:    account.ID,
+                Amount:       leg.Amount,
+                Description:  fmt.Sprintf("Reversal of transaction %d", leg.ID),
+                ReversalOfID: &leg.ID,
+            }
+            if req.Reason != "" {
+                reversal.Description += ": " + req.Reason
+            }
+            if leg.IsCredit() {
+                if account.AvailableBalance() < leg.Amount && !req.AllowNegative {
+                    return newError(ErrInvalid, fmt.Sprintf("Insufficient balance on account %d to take back %.2f", account.ID, leg.Amount))
+                }
+                account.Balance -= leg.Amount
+                reversal.TransactionType = models.TransactionReversalOut
+            } else {
+                account.Balance += leg.Amount
+                reversal.TransactionType = models.TransactionReversalIn
+            }
+            reversal.BalanceAfter = account.Balance
+
+            if err := tx.Save(account).Error; err != nil {
+                return err
+            }
+            if i > 0 {
+                firstID := result.Reversals[0].ID
+                reversal.CounterpartID = &firstID
+            }
+            if err := tx.Create(&reversal).Error; err != nil {
+                return err
+            }
+            result.Reversals = append(result.Reversals, reversal)
+        }
+        if len(result.Reversals) == 2 {
+            if err := linkCounterpart(tx, &result.Reversals[0], result.Reversals[1].ID); err != nil {
+                return err
+            }
+        }
+
+        result.Original = legs
+        result.Accounts = accounts
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    metrics.MoneyMoved(metrics.TypeReversal, result.Accounts[0].Currency, result.Original[0].Amount)
+    return &result, nil
+}
@@ -0,0 +1,68 @@
+// services/schedule.go
+package services
+
+import (
+    "time"
+
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// daysIn returns the number of days in the given month.
+func daysIn(year int, month time.Month) int {
+    return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// monthlyDate returns day `day` of the given month, or the month's last day
+// when it is shorter (e.g. day 31 in April becomes April 30).
+func monthlyDate(year int, month time.Month, day int, clock time.Time) time.Time {
+    // Normalize month overflow (month 13 -> January next year) before clamping
+    first := time.Date(year, month, 1, clock.Hour(), clock.Minute(), clock.Second(), 0, clock.Location())
+    if last := daysIn(first.Year(), first.Month()); day > last {
+        day = last
+    }
+    return first.AddDate(0, 0, day-1)
+}
+
+// FirstOccurrence returns the first execution time of a schedule.
+// Monthly schedules run on the first matching day on or after StartAt.
+func FirstOccurrence(s *models.ScheduledTransfer) time.Time {
+    if s.Frequency != models.FrequencyMonthly {
+        return s.StartAt
+    }
+    candidate := monthlyDate(s.StartAt.Year(), s.StartAt.Month(), s.DayOfMonth, s.StartAt)
+    if candidate.Before(s.StartAt) {
+        candidate = monthlyDate(s.StartAt.Year(), s.StartAt.Month()+1, s.DayOfMonth, s.StartAt)
+    }
+    return candidate
+}
+
+// NextOccurrence returns the occurrence after prev, or nil when the schedule
+// has no more occurrences (one-off schedules, or past EndAt).
+func NextOccurrence(s *models.ScheduledTransfer, prev time.Time) *time.Time {
+    var next time.Time
+    switch s.Frequency {
+    case models.FrequencyDaily:
+        next = prev.AddDate(0, 0, 1)
+    case models.FrequencyWeekly:
+        next = prev.AddDate(0, 0, 7)
+    case models.FrequencyMonthly:
+        // Always compute from DayOfMonth, so the 31st -> 30th -> 31st instead of drifting
+        next = monthlyDate(prev.Year(), prev.Month()+1, s.DayOfMonth, prev)
+    default:
+        return nil
+    }
+    if s.EndAt != nil && next.After(*s.EndAt) {
+        return nil
+    }
+    return &next
+}
+
+// NextOccurrenceAfter skips occurrences until one falls at or after t.
+// Used when a paused schedule is resumed: missed occurrences are not replayed.
+func NextOccurrenceAfter(s *models.ScheduledTransfer, from, t time.Time) *time.Time {
+    next := &from
+    for next != nil && next.Before(t) {
+        next = NextOccurrence(s, *next)
+    }
+    return next
+}
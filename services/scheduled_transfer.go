@@ -0,0 +1,147 @@
+// services/scheduled_transfer.go
+package services
+
+import (
+    "errors"
+    "fmt"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// RunDueScheduledTransfers executes every active scheduled transfer whose
+// next run time has passed. Each one runs in its own DB transaction and is
+// locked with SKIP LOCKED, so several workers can run side by side.
+// It returns the number of schedules processed.
+func RunDueScheduledTransfers(db *gorm.DB, limits config.LimitsConfig, approval config.ApprovalConfig, cfg config.SchedulerConfig, now time.Time) (int, error) {
+    var ids []uint
+    if err := db.Model(&models.ScheduledTransfer{}).
+        Where("status = ? AND next_run_at <= ?", models.ScheduleStatusActive, now).
+        Order("next_run_at").Limit(cfg.BatchSize).
+        Pluck("id", &ids).Error; err != nil {
+        return 0, err
+    }
+
+    processed := 0
+    for _, id := range ids {
+        ran, err := runScheduledTransfer(db, limits, approval, cfg, id, now)
+        if err != nil {
+            return processed, fmt.Errorf("scheduled transfer %d: %w", id, err)
+        }
+        if ran {
+            processed++
+        }
+    }
+    return processed, nil
+}
+
+// runScheduledTransfer attempts the pending occurrence of one schedule.
+// Business failures (insufficient funds, frozen account, limits) are recorded
+// on the schedule and retried later; only database errors are returned.
+// Amounts at or above APPROVAL_TRANSFER_THRESHOLD (which may have been lowered
+// since the schedule was created) are never executed without a checker.
+func runScheduledTransfer(db *gorm.DB, limits config.LimitsConfig, approval config.ApprovalConfig, cfg config.SchedulerConfig, id uint, now time.Time) (bool, error) {
+    ran := false
+    var transferred *TransferResult
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var st models.ScheduledTransfer
+        err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+            Where("id = ? AND status = ? AND next_run_at <= ?", id, models.ScheduleStatusActive, now).
+            First(&st).Error
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return nil // Another worker took it, or it was paused/cancelled meanwhile
+        }
+        if err != nil {
+            return err
+        }
+        ran = true
+
+        occurrence := now
+        if st.NextOccurrence != nil {
+            occurrence = *st.NextOccurrence
+        }
+        run := models.ScheduledTransferRun{
+            ScheduledTransferID: st.ID,
+            Occurrence:          occurrence,
+            Attempt:             st.Attempts + 1,
+        }
+
+        description := fmt.Sprintf("Scheduled transfer #%d", st.ID)
+        if st.Description != "" {
+            description += ": " + st.Description
+        }
+        var result *TransferResult
+        var transferErr error
+        if TransferNeedsApproval(approval, st.Amount) {
+            transferErr = newError(ErrForbidden, "Amount needs approval and cannot be transferred by a schedule")
+        } else {
+            result, transferErr = Transfer(tx, limits, TransferRequest{
+                FromAccountID:  st.FromAccountID,
+                ToAccountID:    st.ToAccountID,
+                Amount:         st.Amount,
+                OutDescription: description,
+                InDescription:  description,
+            })
+        }
+
+        st.LastRunAt = &now
+        if transferErr == nil {
+            run.Succeeded = true
+            transferred = result
+            run.TransactionID = &result.OutTx.ID
+            st.ExecutionCount++
+            st.Attempts = 0
+            st.LastError = ""
+            advanceSchedule(&st, occurrence)
+        } else {
+            var svcErr *Error
+            var limitErr *LimitError
+            if !errors.As(transferErr, &svcErr) && !errors.As(transferErr, &limitErr) {
+                return transferErr
+            }
+            run.Error = transferErr.Error()
+            st.Attempts++
+            st.LastError = transferErr.Error()
+            if st.Attempts >= cfg.MaxAttempts {
+                // Give up on this occurrence. One-offs fail; recurring ones move on.
+                if st.Frequency == models.FrequencyOnce {
+                    st.Status = models.ScheduleStatusFailed
+                    st.NextOccurrence = nil
+                    st.NextRunAt = nil
+                } else {
+                    st.Attempts = 0
+                    advanceSchedule(&st, occurrence)
+                }
+            } else {
+                retryAt := now.Add(cfg.RetryDelay)
+                st.NextRunAt = &retryAt
+            }
+        }
+
+        if err := tx.Create(&run).Error; err != nil {
+            return err
+        }
+        return tx.Save(&st).Error
+    })
+    if err == nil && transferred != nil {
+        transferred.RecordMetrics()
+    }
+    return ran, err
+}
+
+// advanceSchedule moves a schedule to its next occurrence, or completes it.
+func advanceSchedule(st *models.ScheduledTransfer, occurrence time.Time) {
+    next := NextOccurrence(st, occurrence)
+    if next == nil {
+        st.Status = models.ScheduleStatusCompleted
+        st.NextOccurrence = nil
+        st.NextRunAt = nil
+        return
+    }
+    st.NextOccurrence = next
+    st.NextRunAt = next
+}
@@ -0,0 +1,220 @@
+// services/transfer.go
+package services
+
+import (
+    "encoding/json"
+    "errors"
+    "strconv"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/metrics"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// TransferRequest describes a move of funds between two accounts.
+// Empty descriptions get the default "Transfer to/from account N" text.
+type TransferRequest struct {
+    FromAccountID  uint    `json:"from_account_id"`
+    ToAccountID    uint    `json:"to_account_id"`
+    Amount         float64 `json:"amount"`
+    OutDescription string  `json:"out_description,omitempty"`
+    InDescription  string  `json:"in_description,omitempty"`
+}
+
+// TransferResult holds the updated accounts and the two logged transactions.
+type TransferResult struct {
+    FromAccount  models.Account      `json:"from_account"`
+    ToAccount    models.Account      `json:"to_account"`
+    OutTx        models.Transaction  `json:"out_tx"`
+    InTx         models.Transaction  `json:"in_tx"`
+    OverdraftFee *models.Transaction `json:"overdraft_fee,omitempty"` // Charged when the transfer takes the source below zero
+}
+
+// RecordMetrics counts a committed transfer, and its overdraft fee if any.
+func (r *TransferResult) RecordMetrics() {
+    metrics.MoneyMoved(metrics.TypeTransfer, r.FromAccount.Currency, r.OutTx.Amount)
+    if r.OverdraftFee != nil {
+        metrics.MoneyMoved(metrics.TypeOverdraft, r.FromAccount.Currency, r.OverdraftFee.Amount)
+    }
+}
+
+// Transfer moves funds atomically. Account status, balance and outflow limits
+// are all checked inside the same DB transaction as the debit. If db is
+// already a transaction, the work runs in a savepoint.
+func Transfer(db *gorm.DB, limits config.LimitsConfig, req TransferRequest) (*TransferResult, error) {
+    if req.FromAccountID == req.ToAccountID {
+        return nil, newError(ErrInvalid, "Cannot transfer to the same account")
+    }
+    if req.Amount <= 0 {
+        return nil, newError(ErrInvalid, "Amount must be positive")
+    }
+
+    var result TransferResult
+    err := db.Transaction(func(tx *gorm.DB) error {
+        // Lock both rows in ID order so opposite transfers cannot deadlock
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+            Where("id IN ?", []uint{req.FromAccountID, req.ToAccountID}).
+            Order("id").Find(&[]models.Account{}).Error; err != nil {
+            return err
+        }
+
+        from, to := &result.FromAccount, &result.ToAccount
+        if err := tx.First(from, req.FromAccountID).Error; err != nil {
+            return notFound(err, "Source account not found")
+        }
+        if err := tx.First(to, req.ToAccountID).Error; err != nil {
+            return notFound(err, "Destination account not found")
+        }
+
+        // Frozen or closed accounts cannot send; closed accounts cannot receive
+        if !from.CanDebit() {
+            return newError(ErrForbidden, "Account "+strconv.Itoa(int(from.ID))+" is "+from.Status+" and cannot be debited")
+        }
+        if !to.CanCredit() {
+            return newError(ErrForbidden, "Account "+strconv.Itoa(int(to.ID))+" is "+to.Status+" and cannot receive funds")
+        }
+
+        // No currency conversion: both sides must hold the same currency
+        if from.Currency != to.Currency {
+            return newError(ErrInvalid, "Accounts hold different currencies ("+from.Currency+" and "+to.Currency+")")
+        }
+
+        // Check the available balance, overdraft included
+        if from.AvailableBalance() < req.Amount {
+            return newError(ErrInvalid, "Insufficient balance")
+        }
+
+        // Check limits
+        if err := CheckOutflowLimits(tx, from, req.Amount, true, limits); err != nil {
+            return err
+        }
+
+        // Perform transfer
+        fromBefore := from.Balance
+        from.Balance -= req.Amount
+        to.Balance += req.Amount
+        if err := tx.Save(from).Error; err != nil {
+            return err
+        }
+        if err := tx.Save(to).Error; err != nil {
+            return err
+        }
+
+        // Log transactions
+        if req.OutDescription == "" {
+            req.OutDescription = "Transfer to account " + strconv.Itoa(int(to.ID))
+        }
+        if req.InDescription == "" {
+            req.InDescription = "Transfer from account " + strconv.Itoa(int(from.ID))
+        }
+        result.OutTx = models.Transaction{
+            AccountID:       from.ID,
+            TransactionType: models.TransactionTransferOut,
+            Amount:          req.Amount,
+            Description:     req.OutDescription,
+            BalanceAfter:    from.Balance,
+        }
+        if err := tx.Create(&result.OutTx).Error; err != nil {
+            return err
+        }
+        result.InTx = models.Transaction{
+            AccountID:       to.ID,
+            TransactionType: models.TransactionTransferIn,
+            Amount:          req.Amount,
+            Description:     req.InDescription,
+            BalanceAfter:    to.Balance,
+            CounterpartID:   &result.OutTx.ID,
+        }
+        if err := tx.Create(&result.InTx).Error; err != nil {
+            return err
+        }
+        if err := linkCounterpart(tx, &result.OutTx, result.InTx.ID); err != nil {
+            return err
+        }
+        var err error
+        result.OverdraftFee, err = chargeOverdraftFee(tx, from, fromBefore)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+    return &result, nil
+}
+
+// linkCounterpart points the first leg of a transfer at the second, which only
+// has an ID once it is created. Reversals use the link to undo both legs.
+func linkCounterpart(tx *gorm.DB, first *models.Transaction, secondID uint) error {
+    first.CounterpartID = &secondID
+    return tx.Model(first).Update("counterpart_id", secondID).Error
+}
+
+// TransferNeedsApproval reports whether a transfer is large enough to need a
+// checker's approval (APPROVAL_TRANSFER_THRESHOLD).
+func TransferNeedsApproval(cfg config.ApprovalConfig, amount float64) bool {
+    return cfg.TransferThreshold > 0 && amount >= cfg.TransferThreshold
+}
+
+// ProposeTransfer puts a large transfer up for approval. The maker is the
+// owner of the source account, who passed AuthorizeTransfer. Balance and
+// limits are checked when the transfer runs.
+func ProposeTransfer(db *gorm.DB, cfg config.ApprovalConfig, req TransferRequest, makerID uint) (*models.PendingAction, error) {
+    if req.FromAccountID == req.ToAccountID {
+        return nil, newError(ErrInvalid, "Cannot transfer to the same account")
+    }
+    if req.Amount <= 0 {
+        return nil, newError(ErrInvalid, "Amount must be positive")
+    }
+    var destination models.Account
+    if err := db.First(&destination, req.ToAccountID).Error; err != nil {
+        return nil, notFound(err, "Destination account not found")
+    }
+    return ProposeAction(db, cfg, ActionProposal{
+        Kind:       models.ActionLargeTransfer,
+        EntityType: "account",
+        EntityID:   req.FromAccountID,
+        Payload:    req,
+        MakerID:    makerID,
+    })
+}
+
+// executeTransfer runs an approved transfer.large action.
+func executeTransfer(tx *gorm.DB, env ActionEnv, action *models.PendingAction) (interface{}, error) {
+    var req TransferRequest
+    if err := json.Unmarshal([]byte(action.Payload), &req); err != nil {
+        return nil, err
+    }
+    result, err := Transfer(tx, env.Limits, req)
+    if err != nil {
+        return nil, err
+    }
+    result.RecordMetrics()
+    return result, nil
+}
+
+// AuthorizeTransfer runs the checks a customer-initiated transfer needs on top
+// of Transfer, against the source account's owner: a verified email and, above
+// the step-up threshold, a fresh MFA code. It returns the source account.
+func AuthorizeTransfer(db *gorm.DB, emailCfg config.EmailConfig, mfaCfg config.MFAConfig, fromAccountID uint, amount float64, mfaCode string) (*models.Account, error) {
+    var source models.Account
+    if err := db.First(&source, fromAccountID).Error; err != nil {
+        return nil, notFound(err, "Source account not found")
+    }
+    if err := RequireVerifiedEmail(db, emailCfg, source.UserID); err != nil {
+        return nil, err
+    }
+    if err := CheckStepUpMFA(db, mfaCfg, source.UserID, amount, mfaCode); err != nil {
+        return nil, err
+    }
+    return &source, nil
+}
+
+// notFound turns gorm's ErrRecordNotFound into a service error.
+func notFound(err error, message string) error {
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return newError(ErrNotFound, message)
+    }
+    return err
+}
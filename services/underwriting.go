@@ -0,0 +1,277 @@
+// services/underwriting.go
+package services
+
+import (
+    "math"
+    "time"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// Reason codes explaining an underwriting decision.
+const (
+    ReasonNoAccount         = "NO_ACCOUNT"          // Decline: the applicant has no open account
+    ReasonPrincipalTooHigh  = "PRINCIPAL_TOO_HIGH"  // Decline: above UNDERWRITING_MAX_PRINCIPAL
+    ReasonTermTooLong       = "TERM_TOO_LONG"       // Decline: above UNDERWRITING_MAX_TERM_MONTHS
+    ReasonNoInflow          = "NO_INFLOW"           // Decline: no money came in during the lookback window
+    ReasonPaymentToInflow   = "PAYMENT_TO_INFLOW"   // Decline: the installment takes too much of monthly inflows
+    ReasonThinFile          = "THIN_FILE"           // Refer: oldest account younger than UNDERWRITING_MIN_ACCOUNT_AGE_DAYS
+    ReasonLowAverageBalance = "LOW_AVERAGE_BALANCE" // Refer: below UNDERWRITING_MIN_AVERAGE_BALANCE
+    ReasonHighExposure      = "HIGH_EXPOSURE"       // Refer: total loan balances too high against inflows
+    ReasonAboveAutoLimit    = "ABOVE_AUTO_LIMIT"    // Refer: above UNDERWRITING_AUTO_APPROVE_LIMIT
+)
+
+// CreditProfile is what the engine knows about an applicant. It is stored as
+// JSON on the loan so every decision can be explained later.
+type CreditProfile struct {
+    AccountAgeDays   int     `json:"account_age_days"`   // Age of the oldest open account
+    AverageBalance   float64 `json:"average_balance"`    // Mean end-of-day balance over the lookback window, all accounts
+    MonthlyInflow    float64 `json:"monthly_inflow"`     // Deposits and incoming transfers from other customers, per 30 days
+    MonthlyOutflow   float64 `json:"monthly_outflow"`    // Withdrawals and outgoing transfers to other customers, per 30 days
+    ExistingExposure float64 `json:"existing_exposure"`  // Outstanding on the applicant's open loans
+    Installment      float64 `json:"installment"`        // Monthly payment of the applied loan at the priced rate
+    PaymentToInflow  float64 `json:"payment_to_inflow"`  // Installment / MonthlyInflow
+    ExposureToInflow float64 `json:"exposure_to_inflow"` // (ExistingExposure + principal) / MonthlyInflow
+}
+
+// Underwriting is the engine's verdict on an application.
+type Underwriting struct {
+    Decision     string        // One of the models.Underwriting* constants
+    Score        int           // 300-850
+    InterestRate float64       // Priced annual rate in percent
+    Reasons      []string      // Reason* codes, empty for a clean approval
+    Profile      CreditProfile // Inputs the decision was based on
+}
+
+// scoreBands price an application by its score: the first band whose
+// MinScore is reached adds its Premium to the base rate.
+var scoreBands = []struct {
+    MinScore int
+    Premium  float64
+}{
+    {750, 0},
+    {650, 2},
+    {550, 4},
+    {0, 7},
+}
+
+// Underwrite scores an application against the applicant's account history
+// and the rules in cfg, and prices it within the product's rate band. It only
+// reads; ApplyLoan stores the result.
+func Underwrite(db *gorm.DB, cfg config.UnderwritingConfig, app LoanApplication, product *models.LoanProduct, now time.Time) (*Underwriting, error) {
+    profile, hasAccount, err := buildCreditProfile(db, cfg, app.UserID, now)
+    if err != nil {
+        return nil, err
+    }
+
+    u := Underwriting{Score: creditScore(profile)}
+    u.InterestRate = math.Min(math.Max(priceRate(cfg, u.Score, app.TermMonths), product.MinRate), product.MaxRate)
+    profile.Installment = roundCents(monthlyInstallment(app.Principal, u.InterestRate, app.TermMonths))
+    if profile.MonthlyInflow > 0 {
+        profile.PaymentToInflow = round4(profile.Installment / profile.MonthlyInflow)
+        profile.ExposureToInflow = round4((profile.ExistingExposure + app.Principal) / profile.MonthlyInflow)
+    }
+    u.Profile = profile
+
+    var declines, refers []string
+    if !hasAccount {
+        declines = append(declines, ReasonNoAccount)
+    }
+    if cfg.MaxPrincipal > 0 && app.Principal > cfg.MaxPrincipal {
+        declines = append(declines, ReasonPrincipalTooHigh)
+    }
+    if cfg.MaxTermMonths > 0 && app.TermMonths > cfg.MaxTermMonths {
+        declines = append(declines, ReasonTermTooLong)
+    }
+    if profile.MonthlyInflow <= 0 {
+        declines = append(declines, ReasonNoInflow)
+    } else {
+        if profile.PaymentToInflow > cfg.MaxPaymentToInflow {
+            declines = append(declines, ReasonPaymentToInflow)
+        }
+        if profile.ExposureToInflow > cfg.MaxExposureToInflow {
+            refers = append(refers, ReasonHighExposure)
+        }
+    }
+    if profile.AccountAgeDays < cfg.MinAccountAgeDays {
+        refers = append(refers, ReasonThinFile)
+    }
+    if profile.AverageBalance < cfg.MinAverageBalance {
+        refers = append(refers, ReasonLowAverageBalance)
+    }
+    if cfg.AutoApproveLimit > 0 && app.Principal > cfg.AutoApproveLimit {
+        refers = append(refers, ReasonAboveAutoLimit)
+    }
+
+    switch {
+    case len(declines) > 0:
+        u.Decision = models.UnderwritingDecline
+    case len(refers) > 0:
+        u.Decision = models.UnderwritingRefer
+    default:
+        u.Decision = models.UnderwritingApprove
+    }
+    u.Reasons = append(declines, refers...)
+    return &u, nil
+}
+
+// buildCreditProfile gathers the applicant's history. It also reports whether
+// the applicant has an open account at all.
+func buildCreditProfile(db *gorm.DB, cfg config.UnderwritingConfig, userID uint, now time.Time) (CreditProfile, bool, error) {
+    var profile CreditProfile
+    var accounts []models.Account
+    if err := db.Where("user_id = ? AND status <> ?", userID, models.AccountStatusClosed).Find(&accounts).Error; err != nil {
+        return profile, false, err
+    }
+    if err := db.Model(&models.Loan{}).
+        Where("user_id = ? AND status IN ?", userID, models.OpenLoanStatuses).
+        Select("COALESCE(SUM(outstanding_balance), 0)").Scan(&profile.ExistingExposure).Error; err != nil {
+        return profile, false, err
+    }
+    if len(accounts) == 0 {
+        return profile, false, nil
+    }
+
+    lookback := cfg.LookbackDays
+    if lookback <= 0 {
+        lookback = 90
+    }
+    since := now.AddDate(0, 0, -lookback)
+
+    accountIDs := make([]uint, len(accounts))
+    balance := 0.0
+    oldest := now
+    for i, account := range accounts {
+        accountIDs[i] = account.ID
+        balance += account.Balance
+        if account.CreatedAt.Before(oldest) {
+            oldest = account.CreatedAt
+        }
+    }
+    profile.AccountAgeDays = int(now.Sub(oldest).Hours() / 24)
+
+    var transactions []models.Transaction
+    if err := db.Where("account_id IN ? AND created_at >= ?", accountIDs, since).
+        Order("created_at DESC").Find(&transactions).Error; err != nil {
+        return profile, false, err
+    }
+
+    // Transfers between the applicant's own accounts are neither inflow nor outflow
+    ownIDs := make(map[uint]bool, len(transactions))
+    for _, t := range transactions {
+        ownIDs[t.ID] = true
+    }
+    var inflow, outflow float64
+    for _, t := range transactions {
+        if t.CounterpartID != nil && ownIDs[*t.CounterpartID] {
+            continue
+        }
+        switch t.TransactionType {
+        case models.TransactionDeposit, models.TransactionTransferIn:
+            inflow += t.Amount
+        case models.TransactionWithdrawal, models.TransactionTransferOut:
+            outflow += t.Amount
+        }
+    }
+    profile.MonthlyInflow = roundCents(inflow * 30 / float64(lookback))
+    profile.MonthlyOutflow = roundCents(outflow * 30 / float64(lookback))
+
+    // Walk back from today's balance one day at a time, undoing each day's
+    // transactions, to get every end-of-day balance in the window.
+    start := oldest
+    if start.Before(since) {
+        start = since
+    }
+    days := int(now.Sub(start).Hours()/24) + 1
+    total := 0.0
+    next := 0
+    dayEnd := now
+    for d := 0; d < days; d++ {
+        total += balance
+        dayStart := dayEnd.Add(-24 * time.Hour)
+        for next < len(transactions) && transactions[next].CreatedAt.After(dayStart) {
+            if transactions[next].IsCredit() {
+                balance -= transactions[next].Amount
+            } else {
+                balance += transactions[next].Amount
+            }
+            next++
+        }
+        dayEnd = dayStart
+    }
+    profile.AverageBalance = roundCents(total / float64(days))
+    return profile, true, nil
+}
+
+// creditScore turns a profile into a 300-850 score. Tenure, balances and
+// inflows raise it; spending most of what comes in and existing debt lower it.
+func creditScore(p CreditProfile) int {
+    score := 550.0
+    score += math.Min(float64(p.AccountAgeDays)/30, 24) * 5 // Up to +120 for two years
+    switch {
+    case p.AverageBalance >= 5000:
+        score += 100
+    case p.AverageBalance >= 1000:
+        score += 60
+    case p.AverageBalance >= 100:
+        score += 20
+    }
+    switch {
+    case p.MonthlyInflow >= 5000:
+        score += 80
+    case p.MonthlyInflow >= 2000:
+        score += 50
+    case p.MonthlyInflow >= 500:
+        score += 20
+    }
+    if p.MonthlyInflow > 0 {
+        if spent := p.MonthlyOutflow / p.MonthlyInflow; spent > 0.9 {
+            score -= 60
+        } else if spent > 0.7 {
+            score -= 25
+        }
+        score -= math.Min(p.ExistingExposure/p.MonthlyInflow, 12) * 10 // Up to -120
+    } else if p.ExistingExposure > 0 {
+        score -= 120
+    }
+    return int(math.Max(300, math.Min(850, math.Round(score))))
+}
+
+// priceRate adds the score band's premium, and the long-term premium for terms
+// over three years, to the base rate.
+func priceRate(cfg config.UnderwritingConfig, score, termMonths int) float64 {
+    rate := cfg.BaseRate
+    for _, band := range scoreBands {
+        if score >= band.MinScore {
+            rate += band.Premium
+            break
+        }
+    }
+    if termMonths > 36 {
+        rate += cfg.LongTermPremium
+    }
+    if cfg.MaxRate > 0 && rate > cfg.MaxRate {
+        rate = cfg.MaxRate
+    }
+    return round4(rate)
+}
+
+// monthlyInstallment is the annuity payment for a loan: the same amount every
+// month pays off principal and interest over the term.
+func monthlyInstallment(principal, annualRate float64, months int) float64 {
+    if months <= 0 {
+        return principal
+    }
+    r := annualRate / 100 / 12
+    if r == 0 {
+        return principal / float64(months)
+    }
+    return principal * r / (1 - math.Pow(1+r, -float64(months)))
+}
+
+func roundCents(v float64) float64 { return math.Round(v*100) / 100 }
+
+func round4(v float64) float64 { return math.Round(v*10000) / 10000 }
@@ -0,0 +1,225 @@
+// services/webhooks.go
+package services
+
+import (
+    "bytes"
+    "context"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "io"
+    "net/http"
+    "strconv"
+    "time"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/bhushangupta162/bank_management/config"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// Headers sent with every webhook request.
+const (
+    WebhookHeaderEvent     = "X-Webhook-Event"
+    WebhookHeaderDelivery  = "X-Webhook-Delivery"
+    WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+    WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// SignWebhook returns the signature header value for a payload:
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+// Including the timestamp lets receivers reject replayed requests.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+    mac := hmac.New(sha256.New, []byte(secret))
+    mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+    mac.Write([]byte("."))
+    mac.Write(body)
+    return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookBackoff returns the wait before retry number `attempt` (1-based):
+// base, 2*base, 4*base, ... capped at max.
+func WebhookBackoff(cfg config.WebhookConfig, attempt int) time.Duration {
+    d := cfg.BaseBackoff
+    for i := 1; i < attempt && d < cfg.MaxBackoff; i++ {
+        d *= 2
+    }
+    if d > cfg.MaxBackoff {
+        d = cfg.MaxBackoff
+    }
+    return d
+}
+
+// DispatchOutbox turns undispatched outbox events into one pending delivery per
+// matching subscription. Events are locked with SKIP LOCKED so several
+// dispatchers can run at once.
+func DispatchOutbox(db *gorm.DB, batchSize int, now time.Time) (int, error) {
+    dispatched := 0
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var events []models.OutboxEvent
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+            Where("dispatched_at IS NULL").Order("id").Limit(batchSize).
+            Find(&events).Error; err != nil {
+            return err
+        }
+        if len(events) == 0 {
+            return nil
+        }
+
+        var subs []models.WebhookSubscription
+        if err := tx.Where("active = ?", true).Find(&subs).Error; err != nil {
+            return err
+        }
+
+        for _, event := range events {
+            for _, sub := range subs {
+                if !sub.Matches(event.EventType) {
+                    continue
+                }
+                delivery := models.WebhookDelivery{
+                    SubscriptionID: sub.ID,
+                    EventID:        event.ID,
+                    EventType:      event.EventType,
+                    Status:         models.DeliveryStatusPending,
+                    NextAttemptAt:  now,
+                }
+                if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&delivery).Error; err != nil {
+                    return err
+                }
+            }
+            if err := tx.Model(&models.OutboxEvent{}).Where("id = ?", event.ID).Update("dispatched_at", now).Error; err != nil {
+                return err
+            }
+            dispatched++
+        }
+        return nil
+    })
+    return dispatched, err
+}
+
+// WebhookSender delivers pending webhook deliveries.
+type WebhookSender struct {
+    DB     *gorm.DB
+    Config config.WebhookConfig
+    Client *http.Client
+}
+
+// NewWebhookSender creates a sender with an HTTP client using the configured timeout.
+func NewWebhookSender(db *gorm.DB, cfg config.WebhookConfig) *WebhookSender {
+    return &WebhookSender{DB: db, Config: cfg, Client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// DeliverDue sends every delivery whose next attempt is due. Deliveries are
+// claimed by pushing next_attempt_at forward by a lease before the HTTP call,
+// so no DB lock is held while waiting on the network. They are sent one after
+// another, so the lease covers a full timeout for each of them plus one spare;
+// otherwise another instance could pick up the tail of the batch and send it twice.
+func (s *WebhookSender) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+    var claimed []models.WebhookDelivery
+    err := s.DB.Transaction(func(tx *gorm.DB) error {
+        if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+            Where("status = ? AND next_attempt_at <= ?", models.DeliveryStatusPending, now).
+            Order("next_attempt_at").Limit(s.Config.BatchSize).
+            Find(&claimed).Error; err != nil {
+            return err
+        }
+        if len(claimed) == 0 {
+            return nil
+        }
+        ids := make([]uint, len(claimed))
+        for i, d := range claimed {
+            ids[i] = d.ID
+        }
+        lease := now.Add(s.Config.Timeout * time.Duration(len(claimed)+1))
+        return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", lease).Error
+    })
+    if err != nil {
+        return 0, err
+    }
+
+    for i := range claimed {
+        if err := s.deliver(ctx, &claimed[i]); err != nil {
+            return i, err
+        }
+    }
+    return len(claimed), nil
+}
+
+// deliver makes one attempt and stores the outcome.
+func (s *WebhookSender) deliver(ctx context.Context, d *models.WebhookDelivery) error {
+    var sub models.WebhookSubscription
+    var event models.OutboxEvent
+    if err := s.DB.Unscoped().First(&sub, d.SubscriptionID).Error; err != nil {
+        return err
+    }
+    if err := s.DB.First(&event, d.EventID).Error; err != nil {
+        return err
+    }
+
+    d.Attempts++
+    statusCode, sendErr := s.send(ctx, &sub, d, []byte(event.Payload))
+    d.LastStatusCode = statusCode
+
+    now := time.Now()
+    switch {
+    case sendErr == nil:
+        d.Status = models.DeliveryStatusSucceeded
+        d.DeliveredAt = &now
+        d.LastError = ""
+    case !sub.Active || sub.DeletedAt.Valid:
+        d.Status = models.DeliveryStatusDead
+        d.LastError = "subscription disabled: " + sendErr.Error()
+    case d.Attempts >= s.Config.MaxAttempts:
+        d.Status = models.DeliveryStatusDead
+        d.LastError = sendErr.Error()
+    default:
+        d.LastError = sendErr.Error()
+        d.NextAttemptAt = now.Add(WebhookBackoff(s.Config, d.Attempts))
+    }
+    return s.DB.Save(d).Error
+}
+
+// send POSTs the signed payload. Any non-2xx answer counts as a failure.
+func (s *WebhookSender) send(ctx context.Context, sub *models.WebhookSubscription, d *models.WebhookDelivery, body []byte) (int, error) {
+    if !sub.Active || sub.DeletedAt.Valid {
+        return 0, fmt.Errorf("subscription %d is not active", sub.ID)
+    }
+
+    ctx, cancel := context.WithTimeout(ctx, s.Config.Timeout)
+    defer cancel()
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+    if err != nil {
+        return 0, err
+    }
+    timestamp := time.Now().Unix()
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("User-Agent", "BankXIT-Webhooks/1.0")
+    req.Header.Set(WebhookHeaderEvent, d.EventType)
+    req.Header.Set(WebhookHeaderDelivery, strconv.FormatUint(uint64(d.ID), 10))
+    req.Header.Set(WebhookHeaderTimestamp, strconv.FormatInt(timestamp, 10))
+    req.Header.Set(WebhookHeaderSignature, SignWebhook(sub.Secret, timestamp, body))
+
+    resp, err := s.Client.Do(req)
+    if err != nil {
+        return 0, err
+    }
+    defer resp.Body.Close()
+    io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        return resp.StatusCode, fmt.Errorf("endpoint answered %d", resp.StatusCode)
+    }
+    return resp.StatusCode, nil
+}
+
+// RunWebhooks dispatches the outbox and sends due deliveries. It is the body of the webhook job.
+func (s *WebhookSender) RunWebhooks(ctx context.Context) error {
+    now := time.Now()
+    if _, err := DispatchOutbox(s.DB, s.Config.BatchSize, now); err != nil {
+        return err
+    }
+    _, err := s.DeliverDue(ctx, now)
+    return err
+}
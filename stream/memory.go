@@ -0,0 +1,88 @@
+// stream/memory.go
+package stream
+
+import (
+    "context"
+    "sync"
+
+    "github.com/bhushangupta162/bank_management/metrics"
+)
+
+// MemoryBroker delivers events to subscribers in the same process. Publish
+// never blocks: a subscriber whose buffer is full is dropped, so one slow
+// client cannot hold up the others.
+type MemoryBroker struct {
+    mu     sync.Mutex
+    topics map[string]map[*memorySubscription]struct{}
+    buffer int
+}
+
+type memorySubscription struct {
+    broker *MemoryBroker
+    topic  string
+    events chan Event
+}
+
+// NewMemoryBroker returns a broker that queues up to buffer events per subscriber.
+func NewMemoryBroker(buffer int) *MemoryBroker {
+    if buffer < 1 {
+        buffer = 1
+    }
+    return &MemoryBroker{topics: map[string]map[*memorySubscription]struct{}{}, buffer: buffer}
+}
+
+// Publish sends event to every current subscriber of topic.
+func (b *MemoryBroker) Publish(_ context.Context, topic string, event Event) error {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+
+    for sub := range b.topics[topic] {
+        select {
+        case sub.events <- event:
+        default:
+            b.remove(sub)
+            metrics.StreamDropped()
+        }
+    }
+    return nil
+}
+
+// Subscribe starts receiving events published to topic.
+func (b *MemoryBroker) Subscribe(_ context.Context, topic string) (Subscription, error) {
+    sub := &memorySubscription{broker: b, topic: topic, events: make(chan Event, b.buffer)}
+
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    if b.topics[topic] == nil {
+        b.topics[topic] = map[*memorySubscription]struct{}{}
+    }
+    b.topics[topic][sub] = struct{}{}
+    return sub, nil
+}
+
+// remove unregisters sub and closes its channel. Caller holds the lock.
+func (b *MemoryBroker) remove(sub *memorySubscription) {
+    subs, ok := b.topics[sub.topic]
+    if !ok {
+        return
+    }
+    if _, ok := subs[sub]; !ok {
+        return
+    }
+    delete(subs, sub)
+    if len(subs) == 0 {
+        delete(b.topics, sub.topic)
+    }
+    close(sub.events)
+}
+
+func (s *memorySubscription) Events() <-chan Event {
+    return s.events
+}
+
+// Close ends the subscription. It is safe to call more than once.
+func (s *memorySubscription) Close() {
+    s.broker.mu.Lock()
+    defer s.broker.mu.Unlock()
+    s.broker.remove(s)
+}
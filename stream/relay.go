@@ -0,0 +1,120 @@
+// stream/relay.go
+package stream
+
+import (
+    "context"
+    "encoding/json"
+    "time"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/logging"
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// LateCommitWindow is how far back the relay looks again for outbox events.
+// Outbox IDs are assigned at insert, so a long DB transaction can commit an
+// event with a lower ID after a higher one was already published.
+const LateCommitWindow = 30 * time.Second
+
+// relayBatchSize bounds how many events are read per query.
+const relayBatchSize = 500
+
+// Relay publishes committed transaction.created outbox events to the broker,
+// one topic per account. Reading the outbox means every code path that logs a
+// transaction is covered and nothing is published for rolled-back changes.
+//
+// Each instance runs its own relay; with MemoryBroker every instance then
+// serves its own subscribers. With a shared broker one relay is enough.
+type Relay struct {
+    DB     *gorm.DB
+    Broker Broker
+
+    cursor  uint               // Highest outbox ID published
+    started bool               // Cursor set to the end of the outbox
+    seen    map[uint]time.Time // Published events inside the late commit window
+}
+
+// NewRelay returns a relay that starts at the end of the outbox. Older
+// transactions are served from the database by the stream handler.
+func NewRelay(db *gorm.DB, broker Broker) *Relay {
+    return &Relay{DB: db, Broker: broker, seen: map[uint]time.Time{}}
+}
+
+// relayedTransaction is the part of the event payload the relay routes by.
+type relayedTransaction struct {
+    ID        uint `json:"id"`
+    AccountID uint `json:"account_id"`
+}
+
+// Run publishes the events committed since the last run. It is the body of
+// the stream relay job.
+func (r *Relay) Run(ctx context.Context) error {
+    db := r.DB.WithContext(ctx)
+    if !r.started {
+        if err := db.Model(&models.OutboxEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&r.cursor).Error; err != nil {
+            return err
+        }
+        r.started = true
+        return nil
+    }
+
+    now := time.Now()
+    for {
+        var events []models.OutboxEvent
+        if err := db.Select("id", "created_at", "payload").
+            Where("event_type = ? AND (id > ? OR created_at > ?)", models.EventTransactionCreated, r.cursor, now.Add(-LateCommitWindow)).
+            Where("id NOT IN ?", r.seenIDs()).
+            Order("id").Limit(relayBatchSize).Find(&events).Error; err != nil {
+            return err
+        }
+        for _, event := range events {
+            if err := r.publish(ctx, &event); err != nil {
+                return err
+            }
+            r.seen[event.ID] = event.CreatedAt
+            if event.ID > r.cursor {
+                r.cursor = event.ID
+            }
+        }
+        if len(events) < relayBatchSize {
+            break
+        }
+    }
+
+    for id, createdAt := range r.seen {
+        if now.Sub(createdAt) > LateCommitWindow {
+            delete(r.seen, id)
+        }
+    }
+    return nil
+}
+
+// seenIDs lists the already published events inside the window. It is never
+// empty, since "NOT IN ()" is not valid SQL; outbox IDs start at 1.
+func (r *Relay) seenIDs() []uint {
+    ids := []uint{0}
+    for id := range r.seen {
+        ids = append(ids, id)
+    }
+    return ids
+}
+
+// publish sends the transaction inside the event envelope to its account's
+// topic. An unreadable payload is logged and skipped so it cannot stall the
+// relay; only broker errors are returned, and the event is retried next run.
+func (r *Relay) publish(ctx context.Context, event *models.OutboxEvent) error {
+    var envelope struct {
+        Data json.RawMessage `json:"data"`
+    }
+    var txn relayedTransaction
+    err := json.Unmarshal([]byte(event.Payload), &envelope)
+    if err == nil {
+        err = json.Unmarshal(envelope.Data, &txn)
+    }
+    if err != nil {
+        logging.FromContext(ctx).Error("could not read outbox event", "event_id", event.ID, "error", err)
+        return nil
+    }
+    return r.Broker.Publish(ctx, AccountTopic(txn.AccountID), Event{ID: txn.ID, Type: EventTransaction, Data: envelope.Data})
+}
@@ -0,0 +1,70 @@
+// stream/sent.go
+package stream
+
+import (
+    "time"
+
+    "gorm.io/gorm"
+
+    "github.com/bhushangupta162/bank_management/models"
+)
+
+// SentIDs remembers which of an account's transactions a subscriber already
+// has. Transaction IDs are assigned at insert, so one can commit after a
+// higher ID was sent; filtering by the highest ID sent would drop it. Only IDs
+// that can still show up late are kept: those created within twice
+// LateCommitWindow, which leaves the relay time to publish them.
+type SentIDs struct {
+    ids map[uint]time.Time
+}
+
+// NewSentIDs returns an empty set.
+func NewSentIDs() *SentIDs {
+    return &SentIDs{ids: map[uint]time.Time{}}
+}
+
+// MarkRecent adds the account's transactions with an ID up to untilID that
+// were created inside the window, for a subscriber that got them from a
+// snapshot of the database.
+func (s *SentIDs) MarkRecent(db *gorm.DB, accountID uint, untilID uint64, now time.Time) error {
+    var recent []models.Transaction
+    if err := db.Select("id", "created_at").
+        Where("account_id = ? AND id <= ? AND created_at > ?", accountID, untilID, now.Add(-2*LateCommitWindow)).
+        Find(&recent).Error; err != nil {
+        return err
+    }
+    for _, txn := range recent {
+        s.Add(txn.ID, txn.CreatedAt)
+    }
+    return nil
+}
+
+// Add records a transaction as sent. createdAt decides when it is forgotten.
+func (s *SentIDs) Add(id uint, createdAt time.Time) {
+    s.ids[id] = createdAt
+}
+
+// Has reports whether the transaction was already sent.
+func (s *SentIDs) Has(id uint) bool {
+    _, ok := s.ids[id]
+    return ok
+}
+
+// IDs lists the remembered IDs for a "NOT IN" filter. Like the relay's list it
+// is never empty; transaction IDs start at 1.
+func (s *SentIDs) IDs() []uint {
+    ids := []uint{0}
+    for id := range s.ids {
+        ids = append(ids, id)
+    }
+    return ids
+}
+
+// Prune forgets the transactions that can no longer be committed late.
+func (s *SentIDs) Prune(now time.Time) {
+    for id, createdAt := range s.ids {
+        if now.Sub(createdAt) > 2*LateCommitWindow {
+            delete(s.ids, id)
+        }
+    }
+}
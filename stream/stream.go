@@ -0,0 +1,42 @@
+// stream/stream.go
+package stream
+
+import (
+    "context"
+    "strconv"
+)
+
+// Event types sent to stream subscribers.
+const (
+    EventTransaction = "transaction" // A committed models.Transaction, with balance_after
+    EventBalance     = "balance"     // The account balance when the stream starts
+)
+
+// Event is one message on a topic.
+type Event struct {
+    ID   uint   // Transaction ID; clients resume after it with Last-Event-ID
+    Type string // One of the Event* constants
+    Data []byte // JSON payload
+}
+
+// Broker fans events out to subscribers. MemoryBroker works within one
+// process; a shared broker (Redis, NATS, Postgres LISTEN/NOTIFY, ...) only
+// needs to implement this interface to reach subscribers on other instances.
+type Broker interface {
+    Publish(ctx context.Context, topic string, event Event) error
+    Subscribe(ctx context.Context, topic string) (Subscription, error)
+}
+
+// Subscription receives the events published to one topic after it was created.
+type Subscription interface {
+    // Events is closed when the subscription ends, including when the broker
+    // dropped a subscriber that fell behind. Clients then resume from the
+    // database with the last event ID they saw.
+    Events() <-chan Event
+    Close()
+}
+
+// AccountTopic is the topic carrying an account's transactions.
+func AccountTopic(accountID uint) string {
+    return "account:" + strconv.FormatUint(uint64(accountID), 10)
+}
@@ -0,0 +1,111 @@
+// tracing/tracing.go
+package tracing
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "os"
+
+    "github.com/gin-gonic/gin"
+    "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+    "go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+    "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+    "go.opentelemetry.io/otel/propagation"
+    "go.opentelemetry.io/otel/sdk/resource"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    "go.opentelemetry.io/otel/trace"
+    "google.golang.org/grpc"
+    "gorm.io/gorm"
+    gormtracing "gorm.io/plugin/opentelemetry/tracing"
+
+    "github.com/bhushangupta162/bank_management/config"
+)
+
+// Tracer is used for spans the application starts itself (jobs, services).
+var Tracer trace.Tracer = otel.Tracer("github.com/bhushangupta162/bank_management")
+
+// Setup installs the global tracer provider and W3C trace-context propagation.
+// The returned function flushes pending spans and must be called on shutdown.
+// With the "none" exporter spans are not recorded, but incoming trace headers
+// are still propagated.
+func Setup(cfg config.TracingConfig) (func(context.Context) error, error) {
+    otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+    opts := []sdktrace.TracerProviderOption{
+        sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+        sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+    }
+
+    var closer io.Closer
+    switch cfg.Exporter {
+    case "none", "":
+        return func(context.Context) error { return nil }, nil
+    case "stdout":
+        exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+        if err != nil {
+            return nil, err
+        }
+        // Synchronous so spans show up immediately, e.g. in tests
+        opts = append(opts, sdktrace.WithSyncer(exp))
+    case "file":
+        f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+        if err != nil {
+            return nil, err
+        }
+        exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+        if err != nil {
+            f.Close()
+            return nil, err
+        }
+        closer = f
+        opts = append(opts, sdktrace.WithSyncer(exp))
+    case "otlp":
+        clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint)}
+        exp, err := otlptracehttp.New(context.Background(), clientOpts...)
+        if err != nil {
+            return nil, err
+        }
+        opts = append(opts, sdktrace.WithBatcher(exp))
+    default:
+        return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+    }
+
+    provider := sdktrace.NewTracerProvider(opts...)
+    otel.SetTracerProvider(provider)
+
+    return func(ctx context.Context) error {
+        err := provider.Shutdown(ctx)
+        if closer != nil {
+            if cerr := closer.Close(); err == nil {
+                err = cerr
+            }
+        }
+        return err
+    }, nil
+}
+
+// Middleware starts a server span per request, named after the route
+// pattern, and continues traces from an incoming traceparent header.
+func Middleware(serviceName string) gin.HandlerFunc {
+    return otelgin.Middleware(serviceName)
+}
+
+// GRPCServerOption starts a server span per RPC, named after the full method,
+// and continues traces from incoming traceparent metadata.
+func GRPCServerOption() grpc.ServerOption {
+    return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
+
+// InstrumentDB adds a span per query. Bound values are left out of the
+// recorded SQL so passwords and tokens never reach the trace backend. Queries
+// only join the request's trace when run with db.WithContext(ctx).
+func InstrumentDB(db *gorm.DB) error {
+    return db.Use(gormtracing.NewPlugin(
+        gormtracing.WithoutQueryVariables(),
+        gormtracing.WithoutMetrics(),
+    ))
+}
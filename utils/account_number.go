@@ -0,0 +1,67 @@
+// utils/account_number.go
+package utils
+
+import (
+    "crypto/rand"
+    "errors"
+    "math/big"
+)
+
+// GenerateAccountNumber returns a random all-digit account number of the given
+// length. The last digit is a Luhn check digit, so typos are caught before a
+// database lookup. The first digit is never zero.
+func GenerateAccountNumber(length int) (string, error) {
+    if length < 6 {
+        return "", errors.New("account number length must be at least 6")
+    }
+
+    digits := make([]byte, length-1)
+    for i := range digits {
+        max := int64(10)
+        if i == 0 {
+            max = 9
+        }
+        n, err := rand.Int(rand.Reader, big.NewInt(max))
+        if err != nil {
+            return "", err
+        }
+        if i == 0 {
+            n.Add(n, big.NewInt(1))
+        }
+        digits[i] = byte('0' + n.Int64())
+    }
+
+    return string(digits) + string(rune('0'+luhnCheckDigit(string(digits)))), nil
+}
+
+// ValidateAccountNumber checks the length, the characters and the Luhn check digit.
+func ValidateAccountNumber(number string, length int) bool {
+    if len(number) != length || length < 2 {
+        return false
+    }
+    for _, r := range number {
+        if r < '0' || r > '9' {
+            return false
+        }
+    }
+    body, check := number[:len(number)-1], int(number[len(number)-1]-'0')
+    return luhnCheckDigit(body) == check
+}
+
+// luhnCheckDigit computes the digit that makes body+digit pass the Luhn check.
+func luhnCheckDigit(body string) int {
+    sum := 0
+    double := true // The rightmost digit of the body is doubled once the check digit is appended
+    for i := len(body) - 1; i >= 0; i-- {
+        d := int(body[i] - '0')
+        if double {
+            d *= 2
+            if d > 9 {
+                d -= 9
+            }
+        }
+        sum += d
+        double = !double
+    }
+    return (10 - sum%10) % 10
+}
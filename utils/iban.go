@@ -0,0 +1,93 @@
+// utils/iban.go
+package utils
+
+import (
+    "errors"
+    "fmt"
+    "strings"
+)
+
+var errInvalidIBANInput = errors.New("country must be 2 letters and bank code/account number alphanumeric")
+
+// BuildIBAN assembles an IBAN from a country code, a bank code and an account
+// number, computing the two ISO 13616 check digits (mod-97).
+func BuildIBAN(country, bankCode, accountNumber string) (string, error) {
+    country = strings.ToUpper(country)
+    bban := strings.ToUpper(bankCode + accountNumber)
+    if len(country) != 2 || !isUpperAlpha(country) || bban == "" || !isAlphanumeric(bban) {
+        return "", errInvalidIBANInput
+    }
+    if len(bban) > 30 {
+        return "", errors.New("IBAN would exceed 34 characters")
+    }
+
+    remainder := ibanMod97(bban + country + "00")
+    return fmt.Sprintf("%s%02d%s", country, 98-remainder, bban), nil
+}
+
+// NormalizeIBAN strips spaces and upper-cases an IBAN.
+func NormalizeIBAN(iban string) string {
+    return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(iban), " ", ""))
+}
+
+// ValidateIBAN checks the structure and the mod-97 checksum of an IBAN.
+// Spaces are ignored. Country-specific BBAN lengths are not checked.
+func ValidateIBAN(iban string) bool {
+    iban = NormalizeIBAN(iban)
+    if len(iban) < 15 || len(iban) > 34 {
+        return false
+    }
+    if !isUpperAlpha(iban[:2]) || !isDigits(iban[2:4]) || !isAlphanumeric(iban[4:]) {
+        return false
+    }
+    // Move the first four characters to the end; the result must be 1 mod 97.
+    return ibanMod97(iban[4:]+iban[:4]) == 1
+}
+
+// LooksLikeIBAN reports whether s starts like an IBAN (two letters, two digits).
+func LooksLikeIBAN(s string) bool {
+    s = NormalizeIBAN(s)
+    return len(s) >= 4 && isUpperAlpha(s[:2]) && isDigits(s[2:4])
+}
+
+// ibanMod97 computes the value mod 97 of s with letters replaced by 10..35,
+// processing one character at a time so the number never overflows.
+func ibanMod97(s string) int {
+    remainder := 0
+    for _, r := range s {
+        switch {
+        case r >= '0' && r <= '9':
+            remainder = (remainder*10 + int(r-'0')) % 97
+        case r >= 'A' && r <= 'Z':
+            remainder = (remainder*100 + int(r-'A') + 10) % 97
+        }
+    }
+    return remainder
+}
+
+func isUpperAlpha(s string) bool {
+    for _, r := range s {
+        if r < 'A' || r > 'Z' {
+            return false
+        }
+    }
+    return s != ""
+}
+
+func isDigits(s string) bool {
+    for _, r := range s {
+        if r < '0' || r > '9' {
+            return false
+        }
+    }
+    return s != ""
+}
+
+func isAlphanumeric(s string) bool {
+    for _, r := range s {
+        if !(r >= '0' && r <= '9') && !(r >= 'A' && r <= 'Z') {
+            return false
+        }
+    }
+    return s != ""
+}
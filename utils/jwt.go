@@ -2,6 +2,7 @@
 package utils
 
 import (
+    "errors"
     "time"
 
     "github.com/golang-jwt/jwt/v4"
@@ -11,11 +12,63 @@ import (
 // In a production app, store this securely (e.g., environment variable).
 var JwtSecret = []byte("your_secret_key")
 
+// Token types carried in the "typ" claim.
+const (
+    TokenTypeAccess       = "access"
+    TokenTypeMFAChallenge = "mfa_challenge"
+)
+
 // GenerateToken generates a JWT token for a given user ID.
 func GenerateToken(userID uint) (string, error) {
     token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
         "user_id": userID,
+        "typ":     TokenTypeAccess,
         "exp":     time.Now().Add(72 * time.Hour).Unix(), // Token valid for 72 hours
     })
     return token.SignedString(JwtSecret)
 }
+
+// GenerateMFAChallengeToken issues the short-lived token returned by /login
+// when the user has two-factor authentication enabled. It can only be
+// exchanged for an access token at /login/mfa.
+func GenerateMFAChallengeToken(userID uint, ttl time.Duration) (string, error) {
+    token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+        "user_id": userID,
+        "typ":     TokenTypeMFAChallenge,
+        "exp":     time.Now().Add(ttl).Unix(),
+    })
+    return token.SignedString(JwtSecret)
+}
+
+// ParseToken validates a signed token of the expected type and returns its user ID.
+// Tokens issued before the "typ" claim existed are treated as access tokens.
+func ParseToken(tokenString, expectedType string) (uint, error) {
+    token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+        if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+            return nil, errors.New("unexpected signing method")
+        }
+        return JwtSecret, nil
+    })
+    if err != nil || !token.Valid {
+        return 0, errors.New("invalid token")
+    }
+
+    claims, ok := token.Claims.(jwt.MapClaims)
+    if !ok {
+        return 0, errors.New("invalid token claims")
+    }
+
+    typ, _ := claims["typ"].(string)
+    if typ == "" {
+        typ = TokenTypeAccess
+    }
+    if typ != expectedType {
+        return 0, errors.New("wrong token type")
+    }
+
+    userID, ok := claims["user_id"].(float64)
+    if !ok || userID <= 0 {
+        return 0, errors.New("invalid token subject")
+    }
+    return uint(userID), nil
+}
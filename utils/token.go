@@ -0,0 +1,35 @@
+// utils/token.go
+package utils
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/hex"
+    "strings"
+)
+
+// GenerateRandomToken returns n random bytes, hex encoded.
+func GenerateRandomToken(n int) (string, error) {
+    b := make([]byte, n)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(b), nil
+}
+
+// GenerateRecoveryCode returns a human friendly one-time code such as "3f9a-c21e-07bd".
+func GenerateRecoveryCode() (string, error) {
+    raw, err := GenerateRandomToken(6)
+    if err != nil {
+        return "", err
+    }
+    return raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12], nil
+}
+
+// HashToken returns the SHA-256 hex digest of a high-entropy secret such as a
+// recovery code. Only the digest is stored, so a database leak does not expose
+// usable codes. (Passwords use bcrypt instead; they are low-entropy.)
+func HashToken(token string) string {
+    sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(token))))
+    return hex.EncodeToString(sum[:])
+}